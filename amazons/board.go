@@ -2,7 +2,10 @@ package amazons
 
 import (
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/boardgamesai/games/game"
 )
 
 type Space struct {
@@ -112,6 +115,100 @@ func (b *Board) PossibleArrows(from, to Space) []Space {
 	return b2.queenMoves(to.Col, to.Row)
 }
 
+// AllMoves is every complete move the player has: each queen that can move, everywhere it can go,
+// and everywhere it can shoot an arrow from there.
+func (b *Board) AllMoves(st SpaceType) []Move {
+	moves := []Move{}
+
+	for _, from := range b.MovableQueens(st) {
+		for _, to := range b.PossibleMoves(from) {
+			for _, arrow := range b.PossibleArrows(from, to) {
+				moves = append(moves, Move{
+					From:  from,
+					To:    to,
+					Arrow: arrow,
+				})
+			}
+		}
+	}
+
+	return moves
+}
+
+// Territory counts the empty spaces each side's queens can reach in fewer queen moves than the other's.
+// Spaces both can reach equally fast, or neither can reach at all, count for no one.
+func (b *Board) Territory() map[SpaceType]int {
+	white := b.queenDistances(White)
+	black := b.queenDistances(Black)
+	territory := map[SpaceType]int{White: 0, Black: 0}
+
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			if b[i][j] != Empty {
+				continue
+			}
+
+			if white[i][j] < black[i][j] {
+				territory[White]++
+			} else if black[i][j] < white[i][j] {
+				territory[Black]++
+			}
+		}
+	}
+
+	return territory
+}
+
+// Partitioned says whether the queens are walled off from each other, so no empty space can be reached
+// by both sides. From then on each side just fills in its own territory.
+func (b *Board) Partitioned() bool {
+	white := b.queenDistances(White)
+	black := b.queenDistances(Black)
+
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			if b[i][j] == Empty && white[i][j] != unreachable && black[i][j] != unreachable {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+const unreachable = math.MaxInt
+
+// queenDistances is the fewest queen moves it takes any of the side's queens to get to each space,
+// ignoring arrows they'd shoot along the way, or unreachable if they can't get there.
+func (b *Board) queenDistances(st SpaceType) [10][10]int {
+	distances := [10][10]int{}
+	queue := []Space{}
+
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			distances[i][j] = unreachable
+			if b[i][j] == st {
+				distances[i][j] = 0
+				queue = append(queue, Space{i, j})
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+
+		for _, to := range b.queenMoves(from.Col, from.Row) {
+			if distances[to.Col][to.Row] == unreachable {
+				distances[to.Col][to.Row] = distances[from.Col][from.Row] + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	return distances
+}
+
 func (b *Board) DeepCopy() *Board {
 	newBoard := Board{}
 	newBoard = *b
@@ -211,3 +308,8 @@ func moveListContains(spaces []Space, space Space) bool {
 func offBoard(col, row int) bool {
 	return col > 9 || col < 0 || row > 9 || row < 0
 }
+
+// Hash fingerprints the board, so a driver can check its copy against ours.
+func (b *Board) Hash() string {
+	return game.Hash(b)
+}
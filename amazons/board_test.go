@@ -313,6 +313,50 @@ func TestMovableQueens(t *testing.T) {
 	}
 }
 
+func TestAllMoves(t *testing.T) {
+	// White's only queen can go one or two spaces right, then shoot back the way it came or further on.
+	board := GetBoardFromString(trimBoard(`
+            *********B
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            W..*******
+            `))
+
+	expected := []Move{
+		{space(0, 0), space(1, 0), space(2, 0)},
+		{space(0, 0), space(1, 0), space(0, 0)},
+		{space(0, 0), space(2, 0), space(1, 0)},
+		{space(0, 0), space(2, 0), space(0, 0)},
+	}
+
+	moves := board.AllMoves(White)
+	if len(moves) != len(expected) {
+		t.Fatalf("Expected %d moves, got %d: %v", len(expected), len(moves), moves)
+	}
+	for _, m := range expected {
+		found := false
+		for _, m2 := range moves {
+			if m == m2 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected move %s, didn't get it in: %v", m, moves)
+		}
+	}
+
+	if moves := board.AllMoves(Black); len(moves) != 0 {
+		t.Errorf("Expected no moves for boxed-in black, got: %v", moves)
+	}
+}
+
 func TestPossibleArrows(t *testing.T) {
 	tests := []struct {
 		board          string
@@ -677,3 +721,55 @@ func compareSpaceSlices(got, expected []Space) error {
 
 	return nil
 }
+
+func TestTerritory(t *testing.T) {
+	tests := []struct {
+		board       string
+		territory   map[SpaceType]int
+		partitioned bool
+	}{
+		{
+			// The space in between is as close to both queens, so it's no one's.
+			`
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            W.B..*****
+            `,
+			map[SpaceType]int{White: 0, Black: 2},
+			false,
+		},
+		{
+			`
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            **********
+            ..********
+            .*********
+            W*B..*****
+            `,
+			map[SpaceType]int{White: 3, Black: 2},
+			true,
+		},
+	}
+
+	for i, test := range tests {
+		board := GetBoardFromString(trimBoard(test.board))
+		if territory := board.Territory(); !reflect.DeepEqual(territory, test.territory) {
+			t.Errorf("(%d) expected territory %v, got: %v", i, test.territory, territory)
+		}
+		if board.Partitioned() != test.partitioned {
+			t.Errorf("(%d) expected partitioned %t, got: %t", i, test.partitioned, board.Partitioned())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package amazons
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,7 +20,8 @@ type EventSetupPlayer struct {
 }
 
 type EventSetup struct {
-	Players []EventSetupPlayer
+	Players       []EventSetupPlayer
+	StartPosition string `json:",omitempty"`
 }
 
 func (e EventSetup) String() string {
@@ -27,7 +29,12 @@ func (e EventSetup) String() string {
 	for _, p := range e.Players {
 		players = append(players, fmt.Sprintf("%+v", p))
 	}
-	return "Setup " + strings.Join(players, ", ")
+
+	s := "Setup " + strings.Join(players, ", ")
+	if e.StartPosition != "" {
+		s += " from position " + e.StartPosition
+	}
+	return s
 }
 
 type EventMove struct {
@@ -38,3 +45,23 @@ type EventMove struct {
 func (e EventMove) String() string {
 	return fmt.Sprintf("ID %d moves %s", e.ID, e.Move)
 }
+
+// DecodeEvent turns a logged event back into the type it was logged as, e.g. for displaying it.
+func DecodeEvent(e game.Event) fmt.Stringer {
+	var eStr fmt.Stringer
+
+	switch e.Type {
+	case EventTypeSetup:
+		ce := EventSetup{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeMove:
+		ce := EventMove{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	default:
+		eStr = game.CommonEvent(e)
+	}
+
+	return eStr
+}
@@ -1,7 +1,7 @@
 package amazons
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/boardgamesai/games/game"
@@ -20,30 +20,56 @@ func New() *Game {
 }
 
 func (g *Game) Play() error {
+	return g.PlayContext(context.Background())
+}
+
+// PlayContext plays the game until it's over, or until ctx is done, in which case the game is aborted.
+func (g *Game) PlayContext(ctx context.Context) error {
+	return g.Finish(ctx, g.play(ctx, nil))
+}
+
+func (g *Game) play(ctx context.Context, s *game.Snapshot) (err error) {
 	// Wipe out any previous state
 	g.reset()
 
-	// Decide who goes first
-	g.shufflePlayers()
+	playerTurn := 0
+	if s == nil {
+		// Decide who goes first
+		g.seatPlayers()
+		if start, turn := g.startPosition(); start != nil {
+			g.Board = start
+			playerTurn = turn
+		}
+	} else {
+		var err error
+		if playerTurn, err = g.restore(s); err != nil {
+			return err
+		}
+	}
+	g.Start()
 
 	// We need to write down our setup
 	setupEvent := EventSetup{
-		Players: []EventSetupPlayer{},
+		Players:       []EventSetupPlayer{},
+		StartPosition: g.StartPosition(),
 	}
 
+	// Players need to know where we're starting from, even when resuming.
+	start, _ := g.startPosition()
+
 	// Launch the player processes
 	for _, player := range g.Players {
 		defer player.CleanUp()
 		defer g.SetOutput(player.ID, player)
 
 		// This copies files to a tmp dir, runs it, and sends a heartbeat message to verify.
-		err := player.Run()
+		err := player.Run(ctx)
 		if err != nil {
 			return fmt.Errorf("player %s failed to run, err: %s", player, err)
 		}
 
 		// This initializes the game state for this player.
-		err = g.Comms.Setup(player, g.otherPlayer(player))
+		err = g.Comms.Setup(ctx, player, g.otherPlayer(player), start)
 		if err != nil {
 			return fmt.Errorf("player %s failed to setup, err: %s", player, err)
 		}
@@ -57,17 +83,35 @@ func (g *Game) Play() error {
 		setupEvent.Players = append(setupEvent.Players, esp)
 	}
 
-	g.EventLog.AddNone(setupEvent)
+	if s == nil {
+		g.AddNone(setupEvent)
+	}
+
+	// Let everyone know how it went before they're shut down.
+	defer func() {
+		g.GameOver(ctx, err, func(ctx context.Context, p *Player, termination game.Termination) error {
+			return g.Comms.GameOver(ctx, p, termination, g.Places())
+		})
+	}()
+
+	// Everyone warms up before the first move, one at a time
+	for _, player := range g.Players {
+		if err := g.WarmUp(ctx, player); err != nil {
+			g.setWinner(g.otherPlayer(player))
+			return err
+		}
+	}
 
 	// Game is over when someone can't move - a draw is impossible
-	playerTurn := 0
 	for {
+		g.checkpoint(playerTurn)
+
 		player := g.Players[playerTurn]
 		if !g.Board.CanMove(player.Color) {
 			break // Someone can't move, game over
 		}
 
-		move, err := g.Comms.GetMove(player)
+		move, concession, err := g.Comms.GetMove(ctx, player, g.Board.Hash(), g.legalMoves(player), g.playerState(player))
 		if err != nil {
 			g.setWinner(g.otherPlayer(player))
 			switch e := err.(type) {
@@ -81,13 +125,21 @@ func (g *Game) Play() error {
 			return err
 		}
 
+		// Resigning or accepting a draw ends the game instead of a move
+		if over, err := g.Concede(player, concession); over {
+			if err != nil {
+				g.setWinner(g.otherPlayer(player))
+			}
+			return err
+		}
+
 		err = g.Board.ApplyMove(player.Color, move)
 
 		e := EventMove{
 			ID:   player.ID,
 			Move: move,
 		}
-		g.EventLog.AddAll(e)
+		g.AddAll(e)
 
 		if err != nil {
 			g.setWinner(g.otherPlayer(player))
@@ -99,6 +151,15 @@ func (g *Game) Play() error {
 		}
 
 		playerTurn = util.Increment(playerTurn, 0, 1)
+		if g.adjudicate(g.AddPly(), playerTurn) {
+			return nil
+		}
+
+		// Let the player who just moved keep thinking on their opponent's time
+		if p, err := g.Ponder(ctx, []*Player{g.otherPlayer(g.Players[playerTurn])}); err != nil {
+			g.setWinner(g.otherPlayer(p))
+			return err
+		}
 	}
 
 	// Whoever's turn it is when we get here is the loser
@@ -108,23 +169,16 @@ func (g *Game) Play() error {
 }
 
 func (g *Game) Events() []fmt.Stringer {
-	events := make([]fmt.Stringer, len(g.EventLog))
-
-	for i, event := range g.EventLog {
-		var eStr fmt.Stringer
-
-		switch event.Type {
-		case EventTypeSetup:
-			e := EventSetup{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeMove:
-			e := EventMove{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		}
+	return g.EventsFor(game.ViewFull)
+}
 
-		events[i] = eStr
+// EventsFor describes the events seen through v.
+func (g *Game) EventsFor(v game.View) []fmt.Stringer {
+	rawEvents := g.RawEventsFor(v)
+	events := make([]fmt.Stringer, len(rawEvents))
+
+	for i, event := range rawEvents {
+		events[i] = DecodeEvent(event)
 	}
 
 	return events
@@ -138,6 +192,46 @@ func (g *Game) reset() {
 	}
 }
 
+// adjudicate decides the game on territory if it's reached the MaxPlies option, or once the queens are
+// partitioned with the Adjudicate option on, and says whether it did. With the territory even, the player
+// whose turn it is loses, since they'll run out of moves first.
+func (g *Game) adjudicate(capped bool, playerTurn int) bool {
+	player := g.Players[playerTurn]
+	if !g.Board.CanMove(player.Color) {
+		return false // The game is over anyway
+	}
+
+	e := game.EventAdjudication{
+		Basis: "territory",
+	}
+	switch {
+	case capped:
+		e.Reason = g.PlyCapReason()
+	case g.Options().Adjudicate && g.Board.Partitioned():
+		e.Reason = "queens partitioned"
+	default:
+		return false
+	}
+
+	territory := g.Board.Territory()
+	for _, p := range g.Players {
+		e.Scores = append(e.Scores, game.AdjudicationScore{ID: p.ID, Score: territory[p.Color]})
+	}
+
+	other := g.otherPlayer(player)
+	if territory[player.Color] > territory[other.Color] {
+		g.setWinner(player)
+	} else {
+		if territory[player.Color] == territory[other.Color] {
+			e.Msg = fmt.Sprintf("even, so ID %d loses moving first", player.ID)
+		}
+		g.setWinner(other)
+	}
+	g.Adjudicate(e)
+
+	return true
+}
+
 func (g *Game) setWinner(p *Player) {
 	places := []game.Place{
 		{Player: p.Player, Rank: 1},
@@ -146,9 +240,13 @@ func (g *Game) setWinner(p *Player) {
 	g.SetPlaces(places)
 }
 
-func (g *Game) shufflePlayers() {
-	util.Shuffle(g.Players)
+func (g *Game) seatPlayers() {
+	g.SeatPlayers()
+	g.assignSeats()
+}
 
+// assignSeats sets up each player according to their place in the turn order.
+func (g *Game) assignSeats() {
 	colors := []SpaceType{White, Black}
 	for i := 0; i < 2; i++ {
 		g.Players[i].Order = i + 1
@@ -156,6 +254,29 @@ func (g *Game) shufflePlayers() {
 	}
 }
 
+// playerState is sent along with the move request to players who asked for the full state.
+func (g *Game) playerState(p *Player) *PlayerState {
+	if !p.FullState {
+		return nil
+	}
+	return &PlayerState{
+		ID:       p.ID,
+		Color:    p.Color,
+		Order:    p.Order,
+		Opponent: g.otherPlayer(p),
+		Board:    g.Board,
+	}
+}
+
+// legalMoves is sent along with the move request to players who asked for it. It's never nil for them,
+// so having no legal moves can't be mistaken for not having asked.
+func (g *Game) legalMoves(p *Player) []Move {
+	if !p.LegalMoves {
+		return nil
+	}
+	return append([]Move{}, g.Board.AllMoves(p.Color)...)
+}
+
 func (g *Game) otherPlayer(player *Player) *Player {
 	if g.Players[0] == player {
 		return g.Players[1]
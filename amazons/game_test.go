@@ -177,3 +177,46 @@ func TestPlayGame(t *testing.T) {
 		t.Errorf("Got incorrect places, second player: %+v", places[1])
 	}
 }
+
+func TestAdjudicate(t *testing.T) {
+	// A wall of arrows with one gap, which White's first move fills in.
+	position := "B..B..B..B/........../........../........../.*********/........../........../........../........../W..W..W..W W"
+
+	tests := []struct {
+		options game.Options
+		reason  string
+	}{
+		{game.Options{Adjudicate: true}, "queens partitioned"},
+		{game.Options{MaxPlies: 1}, "ply cap of 1 reached"},
+	}
+
+	for _, test := range tests {
+		g := getGame(map[int][]Move{
+			1: {mv(sp(0, 0), sp(0, 1), sp(0, 5))},
+		})
+		g.SetOptions(test.options)
+		if err := g.SetPosition(position); err != nil {
+			t.Fatalf("Couldn't set position: %s", err)
+		}
+		if err := g.Play(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		events := g.Events()
+		e, ok := events[len(events)-1].(game.EventAdjudication)
+		if !ok || g.Termination() != game.TerminationAdjudicated {
+			t.Fatalf("%+v: expected adjudication, got: %s (%s)", test.options, g.Termination(), events[len(events)-1])
+		}
+		if e.Reason != test.reason {
+			t.Errorf("%+v: expected reason %q, got: %q", test.options, test.reason, e.Reason)
+		}
+
+		white := g.Players[0]
+		if e.Scores[0].ID != white.ID || e.Scores[0].Score != 46 || e.Scores[1].Score != 36 {
+			t.Errorf("%+v: expected White to have 46 territory to Black's 36, got: %+v", test.options, e.Scores)
+		}
+		if places := g.Places(); places[0].Player.ID != white.ID || places[0].Rank != 1 {
+			t.Errorf("%+v: expected White to win, got: %+v", test.options, places)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package human
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boardgamesai/games/amazons"
+	"github.com/boardgamesai/games/amazons/ai/driver"
+	"github.com/boardgamesai/games/game"
+)
+
+// Human lets a person play the Game of the Amazons from the terminal, typing the queen to move,
+// where it goes and where it shoots, like "d1 d7 g7".
+type Human struct {
+	t          *game.Terminal
+	concession game.Concession // Typed along with, or instead of, the last move
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+// GetMove lists the queens that can move, and how many moves each has, rather than every move, since
+// there are usually hundreds.
+func (h *Human) GetMove(state driver.State) amazons.Move {
+	h.t.ShowEvents(state.NewEvents, amazons.DecodeEvent)
+	h.t.Printf("\n%s\nYou're %s. Queens that can move: %s\n", render(state.Board), state.Color, summarizeMoves(state.Board.AllMoves(state.Color)))
+
+	move := amazons.Move{}
+	h.concession = h.t.AskMove("Your move (e.g. d1 d7 g7): ", state.DrawOffered(), false, func(answer string) error {
+		squares := strings.FieldsFunc(answer, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '/' || r == ','
+		})
+		if len(squares) != 3 {
+			return fmt.Errorf("need a queen, where it goes and where it shoots, e.g. d1 d7 g7")
+		}
+
+		spaces := []amazons.Space{}
+		for _, s := range squares {
+			col, row, err := game.ParseSquare(s, 10, 10)
+			if err != nil {
+				return err
+			}
+			spaces = append(spaces, amazons.Space{Col: col, Row: row})
+		}
+
+		move = amazons.Move{From: spaces[0], To: spaces[1], Arrow: spaces[2]}
+		if err := state.Board.IsValidMove(state.Color, move); err != nil {
+			return fmt.Errorf("can't move there: %s", err)
+		}
+		return nil
+	})
+
+	return move
+}
+
+// Concession goes out with the move, so typing "resign" or "offer" does what it says.
+func (h *Human) Concession() game.Concession {
+	return h.concession
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, amazons.DecodeEvent)
+	h.t.Printf("\n%s", render(state.Board))
+}
+
+// summarizeMoves lists each queen that can move, in the order they come up, with how many moves it has.
+func summarizeMoves(moves []amazons.Move) string {
+	queens := []amazons.Space{}
+	counts := map[amazons.Space]int{}
+	for _, m := range moves {
+		if counts[m.From] == 0 {
+			queens = append(queens, m.From)
+		}
+		counts[m.From]++
+	}
+
+	summary := []string{}
+	for _, q := range queens {
+		summary = append(summary, fmt.Sprintf("%s (%d)", game.Square(q.Col, q.Row), counts[q]))
+	}
+	return strings.Join(summary, ", ")
+}
+
+func render(b *amazons.Board) string {
+	return game.RenderGrid(10, 10, func(col, row int) string {
+		if b[col][row] == amazons.Empty {
+			return "."
+		}
+		return string(b[col][row])
+	})
+}
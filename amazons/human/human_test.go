@@ -0,0 +1,22 @@
+package human
+
+import (
+	"testing"
+
+	"github.com/boardgamesai/games/amazons"
+)
+
+func TestSummarizeMoves(t *testing.T) {
+	a1 := amazons.Space{Col: 0, Row: 0}
+	b1 := amazons.Space{Col: 1, Row: 0}
+	c1 := amazons.Space{Col: 2, Row: 0}
+	moves := []amazons.Move{
+		{From: c1, To: b1, Arrow: a1},
+		{From: a1, To: b1, Arrow: c1},
+		{From: c1, To: b1, Arrow: c1},
+	}
+
+	if s := summarizeMoves(moves); s != "c1 (2), a1 (1)" {
+		t.Errorf("Expected c1 (2), a1 (1), got: %s", s)
+	}
+}
@@ -0,0 +1,71 @@
+package amazons
+
+import (
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// SetPosition has the game start from a custom position rather than the usual one, or from the
+// usual start again if position is "". Rows go from top to bottom, with "." for an empty space,
+// "W" and "B" for queens and "*" for arrows, followed by the color of whoever moves next.
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the board the game starts from, or nil for the usual one,
+// along with the index into Players of whoever moves first.
+func (g *Game) startPosition() (*Board, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	b, color, _ := parsePosition(g.StartPosition())
+	for i, p := range g.Players {
+		if p.Color == color {
+			return b, i
+		}
+	}
+	return b, 0
+}
+
+func parsePosition(position string) (*Board, SpaceType, error) {
+	rows, fields, err := game.SplitPosition(position, 10, 10)
+	if err != nil {
+		return nil, Empty, err
+	}
+
+	if len(fields) != 1 || (SpaceType(fields[0]) != White && SpaceType(fields[0]) != Black) {
+		return nil, Empty, game.PositionError(position, "expected W or B to move after the board")
+	}
+	color := SpaceType(fields[0])
+
+	b := Board{}
+	counts := map[SpaceType]int{}
+	for i, row := range rows {
+		for j, cell := range row {
+			switch SpaceType(cell) {
+			case ".":
+			case White, Black, Arrow:
+				b[j][9-i] = SpaceType(cell)
+				counts[SpaceType(cell)]++
+			default:
+				return nil, Empty, game.PositionError(position, fmt.Sprintf("unknown space %q", cell))
+			}
+		}
+	}
+
+	if counts[White] != 4 || counts[Black] != 4 {
+		return nil, Empty, game.PositionError(position, "each player needs 4 queens")
+	}
+
+	return &b, color, nil
+}
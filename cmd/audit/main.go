@@ -0,0 +1,82 @@
+// Command audit plays games of Hearts or Liar's Dice, recording every message sent to each player,
+// and checks them against what the game declares its players may know. Any leak of hidden information
+// is printed, along with the event or message field it turned up in. Players are opted in to the legal
+// moves and full state, so those get checked too. It exits with status 1 if any are found.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/factory"
+)
+
+func main() {
+	numGamesFlag := flag.Int("n", 1, "number of games to audit")
+	substituteFlag := flag.Bool("substitute", false, "hand a DQ'd player's seat to a fallback AI and keep auditing")
+	flag.Parse()
+
+	if flag.NArg() < 1 || *numGamesFlag < 1 {
+		log.Fatalf("Usage: go run ./cmd/audit [-n games] <game> [ai files, defaults to the random AIs]")
+	}
+
+	gameName := game.Name(flag.Arg(0))
+	g, err := factory.New(gameName)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	auditable, ok := g.(game.Auditable)
+	if !ok {
+		log.Fatalf("%s doesn't declare what its players may know", gameName)
+	}
+	g.SetOptions(game.Options{
+		Substitute: *substituteFlag,
+	})
+
+	numPlayers := game.Data[gameName].NumPlayers
+	filenames := flag.Args()[1:]
+	if len(filenames) == 0 {
+		path := filepath.Join(string(gameName), "ai", "example", "random", "random.go")
+		for i := 0; i < numPlayers; i++ {
+			filenames = append(filenames, path)
+		}
+	}
+	if len(filenames) != numPlayers {
+		log.Fatalf("%s needs %d AI files, got %d", gameName, numPlayers, len(filenames))
+	}
+
+	players := g.GetPlayers()
+	for i, filename := range filenames {
+		players[i].ID = game.PlayerID(i + 1)
+		players[i].Name = game.FileNameToPlayerName(filename)
+		players[i].Runnable = game.NewRunnablePlayer(string(gameName), filename)
+
+		// Opted in to everything a message can carry, so all of it gets checked
+		players[i].LegalMoves = true
+		players[i].FullState = true
+	}
+
+	total := 0
+	for i := 0; i < *numGamesFlag; i++ {
+		leaks, err := game.Audit(context.Background(), g, auditable.Knowledge())
+		if err != nil {
+			fmt.Printf("Game %d ended with error: %s\n", i+1, err)
+		}
+
+		for _, leak := range leaks {
+			fmt.Printf("Game %d leak: %s\n", i+1, leak)
+		}
+		total += len(leaks)
+	}
+
+	fmt.Printf("%d games audited, %d leaks found\n", *numGamesFlag, total)
+	if total > 0 {
+		os.Exit(1)
+	}
+}
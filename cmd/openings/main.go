@@ -0,0 +1,62 @@
+// Command openings generates a suite of openings for a game from random legal playouts,
+// in the format play.go's -openings flag reads. It's how the bundled suites are made.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/factory"
+	"github.com/boardgamesai/games/util"
+)
+
+func main() {
+	numFlag := flag.Int("n", 50, "number of openings to generate")
+	pliesFlag := flag.Int("plies", 4, "number of random moves in each opening")
+	seedFlag := flag.Uint64("seed", 0, "random seed, so the same suite can be made again (0 picks one)")
+	outFlag := flag.String("o", "", "file to write the suite to (defaults to stdout)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatalf("Usage: go run ./cmd/openings [-n num] [-plies plies] [-seed seed] [-o file] <game>")
+	}
+
+	g, err := factory.New(game.Name(flag.Arg(0)))
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	suiter, ok := g.(game.OpeningSuiter)
+	if !ok {
+		log.Fatalf("%s doesn't support generating openings", flag.Arg(0))
+	}
+
+	seed := *seedFlag
+	if seed == 0 {
+		seed = util.RandSeed()
+	}
+
+	openings, err := game.RandomOpenings(suiter, util.NewRand(seed), *numFlag, *pliesFlag)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	fmt.Fprintf(w, "# %d random %d-ply openings for %s, made with seed %d\n", len(openings), *pliesFlag, flag.Arg(0), seed)
+	if err := game.WriteOpenings(w, openings); err != nil {
+		log.Fatalf("%s", err)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// MatchRequest is what POST /matches takes.
+type MatchRequest struct {
+	Game    game.Name
+	AIs     []string // AI IDs, the first playing as player 1 and so on
+	Options game.Options
+	Seed    uint64
+}
+
+// TournamentRequest is what POST /tournaments takes.
+type TournamentRequest struct {
+	Game    game.Name
+	AIs     []string
+	Options game.Options
+	Rounds  int // Defaults to 1
+}
+
+// TournamentStatus is what GET /tournaments/{id} returns.
+type TournamentStatus struct {
+	Tournament
+	Status    Status
+	Standings []Standing
+}
+
+// Handler serves the API:
+//
+//	GET  /games                    the games, with how many players each takes
+//	POST /ais?game=...&name=...    upload an AI's code as the body, checking it compiles
+//	GET  /ais, /ais/{id}
+//	POST /matches                  queue a match, see MatchRequest
+//	GET  /matches, /matches/{id}
+//	GET  /matches/{id}/events      a finished match's events, ?view=full (default), public or a player ID
+//	GET  /matches/{id}/stream      the match's public events live, over Server-Sent Events (see game.Stream)
+//	GET  /matches/{id}/replay      a finished match as a page that plays it back, offline too (see game.Replay)
+//	POST /tournaments              queue a tournament, see TournamentRequest
+//	GET  /tournaments, /tournaments/{id}
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /games", s.handleGames)
+	mux.HandleFunc("POST /ais", s.handleAddAI)
+	mux.HandleFunc("GET /ais", func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, s.AIs())
+	})
+	mux.HandleFunc("GET /ais/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ai, ok := s.AI(r.PathValue("id"))
+		writeFound(w, ok, ai)
+	})
+	mux.HandleFunc("POST /matches", s.handleAddMatch)
+	mux.HandleFunc("GET /matches", func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, s.Matches())
+	})
+	mux.HandleFunc("GET /matches/{id}", func(w http.ResponseWriter, r *http.Request) {
+		m, ok := s.Match(r.PathValue("id"))
+		writeFound(w, ok, m)
+	})
+	mux.HandleFunc("GET /matches/{id}/events", s.handleEvents)
+	mux.HandleFunc("GET /matches/{id}/stream", s.handleStream)
+	mux.HandleFunc("GET /matches/{id}/replay", s.handleReplay)
+	mux.HandleFunc("POST /tournaments", s.handleAddTournament)
+	mux.HandleFunc("GET /tournaments", func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, s.Tournaments())
+	})
+	mux.HandleFunc("GET /tournaments/{id}", s.handleTournament)
+	return mux
+}
+
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+	games := []game.MetaData{}
+	for _, data := range game.Data {
+		games = append(games, data)
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].Name < games[j].Name })
+	writeResponse(w, http.StatusOK, games)
+}
+
+func (s *Server) handleAddAI(w http.ResponseWriter, r *http.Request) {
+	source, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSourceSize))
+	if err != nil {
+		writeError(w, invalidf("AI code can't be more than %d bytes", MaxSourceSize))
+		return
+	}
+
+	query := r.URL.Query()
+	ai, output, err := s.AddAI(game.Name(query.Get("game")), query.Get("name"), source)
+	if output != "" {
+		writeResponse(w, http.StatusUnprocessableEntity, struct {
+			Error  string
+			Output string
+		}{err.Error(), output})
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusCreated, ai)
+}
+
+func (s *Server) handleAddMatch(w http.ResponseWriter, r *http.Request) {
+	req := MatchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, invalidf("invalid match request: %s", err))
+		return
+	}
+
+	m, err := s.AddMatch(req.Game, req.AIs, req.Options, req.Seed)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusCreated, m)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	view := r.URL.Query().Get("view")
+	if view == "" {
+		view = "full"
+	}
+
+	events, err := s.Events(r.PathValue("id"), view)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, events)
+}
+
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	stream, err := s.Stream(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	stream.ServeHTTP(w, r)
+}
+
+func (s *Server) handleReplay(w http.ResponseWriter, r *http.Request) {
+	replay, err := s.Replay(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := replay.WriteHTML(w); err != nil {
+		log.Printf("couldn't write replay: %s\n", err)
+	}
+}
+
+func (s *Server) handleAddTournament(w http.ResponseWriter, r *http.Request) {
+	req := TournamentRequest{Rounds: 1}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, invalidf("invalid tournament request: %s", err))
+		return
+	}
+
+	t, err := s.AddTournament(req.Game, req.AIs, req.Options, req.Rounds)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusCreated, t)
+}
+
+func (s *Server) handleTournament(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.Tournament(r.PathValue("id"))
+	if !ok {
+		writeError(w, errNotFound)
+		return
+	}
+
+	standings, status := s.Standings(t)
+	writeResponse(w, http.StatusOK, TournamentStatus{
+		Tournament: t,
+		Status:     status,
+		Standings:  standings,
+	})
+}
+
+func writeFound(w http.ResponseWriter, ok bool, v interface{}) {
+	if !ok {
+		writeError(w, errNotFound)
+		return
+	}
+	writeResponse(w, http.StatusOK, v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, errNotFound) {
+		status = http.StatusNotFound
+	} else if errors.As(err, &invalidError{}) {
+		status = http.StatusBadRequest
+	} else {
+		log.Printf("error handling request: %s\n", err)
+	}
+
+	writeResponse(w, status, struct{ Error string }{err.Error()})
+}
+
+func writeResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,57 @@
+// Command serve runs a local match server with a JSON API for uploading AIs, running matches and
+// tournaments between them on a pool of workers, and fetching the results and event logs. Everything
+// is kept in a local directory, so it needs nothing else running. See Handler for the endpoints.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+func main() {
+	addrFlag := flag.String("addr", "localhost:8080", "address to serve the API on")
+	dirFlag := flag.String("dir", "serve-data", "directory to keep the AIs, matches and tournaments in")
+	workersFlag := flag.Int("workers", 2, "number of matches to play at once")
+	flag.Parse()
+
+	if flag.NArg() != 0 || *workersFlag < 1 {
+		log.Fatalf("Usage: go run ./cmd/serve [-addr addr] [-dir dir] [-workers n]")
+	}
+
+	s, err := NewServer(*dirFlag, *workersFlag)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	// Ctrl-C stops the server, and any matches cut off are played again next time.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.Run(ctx)
+	}()
+
+	httpServer := &http.Server{Addr: *addrFlag, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving on %s, keeping everything in %s\n", *addrFlag, *dirFlag)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("%s", err)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,783 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/factory"
+	"github.com/pborman/uuid"
+)
+
+// MaxSourceSize is the most AI code we'll take, the same limit as on boardgames.ai.
+const MaxSourceSize = 1 << 20
+
+// Status is where a match is at. Tournaments are running until all their matches are done.
+type Status string
+
+const (
+	StatusQueued  = Status("queued")
+	StatusRunning = Status("running")
+	StatusDone    = Status("done")
+)
+
+// AI is an uploaded AI file, which compiled against its game's driver when it was uploaded.
+type AI struct {
+	ID       string
+	Game     game.Name
+	Name     string
+	Uploaded time.Time
+}
+
+// Match is a single game between uploaded AIs, played by one of the workers. Player IDs follow the
+// order of AIs, so the first plays as ID 1, and so on, wherever they end up sitting.
+type Match struct {
+	ID          string
+	Tournament  string `json:",omitempty"`
+	Game        game.Name
+	AIs         []string
+	Options     game.Options
+	Seed        uint64 `json:",omitempty"` // For the deals and rolls, 0 picks a new one
+	Status      Status
+	Created     time.Time
+	Started     *time.Time       `json:",omitempty"`
+	Finished    *time.Time       `json:",omitempty"`
+	Places      []game.Place     `json:",omitempty"`
+	Termination game.Termination `json:",omitempty"`
+	Error       string           `json:",omitempty"` // What the game ended with, like a DQ, if anything
+}
+
+// LoggedEvent is an event from a finished match along with how it reads, as play.go shows it.
+type LoggedEvent struct {
+	game.Event
+	Text string
+}
+
+// Tournament plays every combination of its AIs against each other, Rounds times over.
+type Tournament struct {
+	ID      string
+	Game    game.Name
+	AIs     []string
+	Rounds  int
+	Options game.Options
+	Created time.Time
+	Matches []string
+}
+
+// Standing is how an AI has done in a tournament so far.
+type Standing struct {
+	AI       string
+	Name     string
+	Played   int
+	Ranks    map[int]int // How many times it finished in each place
+	MeanRank float64     `json:",omitempty"`
+	Errors   int         `json:",omitempty"` // Matches that ended with an error, like a DQ
+}
+
+// Server keeps the AIs, matches and tournaments in a directory, and plays queued matches on a pool of
+// workers. Everything's kept in memory too, and written through on every change, so a restarted server
+// picks up where it left off, playing again any match that was cut off.
+type Server struct {
+	dir     string
+	workers int
+
+	mu          sync.Mutex
+	wake        *sync.Cond
+	queue       []string // Match IDs, oldest first
+	stopped     bool
+	ais         map[string]*AI
+	matches     map[string]*Match
+	tournaments map[string]*Tournament
+	streams     map[string]*game.Stream // For matches that haven't finished, made when someone asks
+
+	// How AIs are checked and run, which tests swap out
+	build    func(gameName game.Name, path string) (string, error)
+	runnable func(gameName game.Name, path string) game.Runnable
+}
+
+func NewServer(dir string, workers int) (*Server, error) {
+	s := &Server{
+		dir:         dir,
+		workers:     workers,
+		ais:         map[string]*AI{},
+		matches:     map[string]*Match{},
+		tournaments: map[string]*Tournament{},
+		streams:     map[string]*game.Stream{},
+		build: func(gameName game.Name, path string) (string, error) {
+			return game.NewRunnablePlayer(string(gameName), path).Build()
+		},
+		runnable: func(gameName game.Name, path string) game.Runnable {
+			return game.NewRunnablePlayer(string(gameName), path)
+		},
+	}
+	s.wake = sync.NewCond(&s.mu)
+
+	for _, sub := range []string{"ais", "matches", "events", "tournaments"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Run plays queued matches until ctx is done. A match that's cut off is queued to be played again
+// from the start next time.
+func (s *Server) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.stopped = false
+	s.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		s.stopped = true
+		s.mu.Unlock()
+		s.wake.Broadcast()
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Server) work(ctx context.Context) {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.stopped {
+			s.wake.Wait()
+		}
+		if s.stopped {
+			s.mu.Unlock()
+			return
+		}
+
+		m := s.matches[s.queue[0]]
+		s.queue = s.queue[1:]
+		now := time.Now()
+		m.Status = StatusRunning
+		m.Started = &now
+		s.saveMatch(m)
+		match := *m
+		s.mu.Unlock()
+
+		s.play(ctx, match)
+	}
+}
+
+// play plays the match and records how it went. It works on a copy, which is fine since nothing
+// else changes a running match.
+func (s *Server) play(ctx context.Context, m Match) {
+	g, err := factory.New(m.Game)
+	if err != nil {
+		s.finish(m, nil, err)
+		return
+	}
+
+	players := g.GetPlayers()
+	s.mu.Lock()
+	for i, aiID := range m.AIs {
+		players[i].ID = game.PlayerID(i + 1)
+		players[i].Name = s.ais[aiID].Name
+		players[i].Runnable = s.runnable(m.Game, s.sourcePath(aiID))
+	}
+	g.AddObserver(s.liveStream(m.ID, m.Game))
+	s.mu.Unlock()
+	defer s.closeStream(m.ID)
+
+	g.SetOptions(m.Options)
+	g.SetSeed(m.Seed)
+	err = g.PlayContext(ctx)
+
+	// We're shutting down, so it goes back in the queue for next time
+	if g.Termination() == game.TerminationAborted && ctx.Err() != nil {
+		s.mu.Lock()
+		m.Status = StatusQueued
+		m.Started = nil
+		s.matches[m.ID] = &m
+		s.saveMatch(&m)
+		s.mu.Unlock()
+		return
+	}
+
+	s.finish(m, g, err)
+}
+
+func (s *Server) finish(m Match, g game.Playable, err error) {
+	if g != nil {
+		views := map[string]game.View{
+			"full":   game.ViewFull,
+			"public": game.ViewPublic, // Before any reveal, which Stream counts on
+		}
+		for i := range m.AIs {
+			views[strconv.Itoa(i+1)] = game.ViewPlayer(game.PlayerID(i + 1))
+		}
+
+		events := map[string][]LoggedEvent{}
+		for name, v := range views {
+			raw := g.RawEventsFor(v)
+			text := g.EventsFor(v)
+			events[name] = []LoggedEvent{}
+			for i, e := range raw {
+				events[name] = append(events[name], LoggedEvent{Event: e, Text: text[i].String()})
+			}
+		}
+		if err := writeJSON(s.eventsPath(m.ID), events); err != nil {
+			log.Printf("couldn't save events for match %s: %s\n", m.ID, err)
+		}
+
+		m.Places = g.Places()
+		m.Termination = g.Termination()
+	}
+
+	now := time.Now()
+	m.Status = StatusDone
+	m.Finished = &now
+	if err != nil {
+		m.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	s.matches[m.ID] = &m
+	s.saveMatch(&m)
+	s.mu.Unlock()
+}
+
+// AddAI saves an AI's code as long as it compiles, returning the compiler's output if it doesn't.
+func (s *Server) AddAI(gameName game.Name, name string, source []byte) (*AI, string, error) {
+	if _, ok := game.Data[gameName]; !ok {
+		return nil, "", invalidf("unknown game: %s", gameName)
+	}
+	if name == "" {
+		return nil, "", invalidf("an AI needs a name")
+	}
+
+	ai := &AI{
+		ID:       uuid.NewRandom().String(),
+		Game:     gameName,
+		Name:     name,
+		Uploaded: time.Now(),
+	}
+	path := s.sourcePath(ai.ID)
+	if err := os.WriteFile(path, source, 0600); err != nil {
+		return nil, "", err
+	}
+
+	output, err := s.build(gameName, path)
+	if err == nil && output != "" {
+		err = invalidf("%s doesn't compile", name)
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, output, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ais[ai.ID] = ai
+	if err := writeJSON(filepath.Join(s.dir, "ais", ai.ID+".json"), ai); err != nil {
+		return nil, "", err
+	}
+
+	return ai, "", nil
+}
+
+// AddMatch queues a match between the AIs, the first playing as player 1 and so on.
+func (s *Server) AddMatch(gameName game.Name, aiIDs []string, options game.Options, seed uint64) (*Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAIs(gameName, aiIDs, game.Data[gameName].NumPlayers); err != nil {
+		return nil, err
+	}
+
+	m := s.newMatch(gameName, aiIDs, options, seed, "")
+	return m, s.saveMatch(m)
+}
+
+// AddTournament queues a match for every combination of the AIs, rounds times over.
+func (s *Server) AddTournament(gameName game.Name, aiIDs []string, options game.Options, rounds int) (*Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	numPlayers := game.Data[gameName].NumPlayers
+	if len(aiIDs) < numPlayers {
+		return nil, invalidf("%s needs at least %d AIs", gameName, numPlayers)
+	}
+	if err := s.checkAIs(gameName, aiIDs, len(aiIDs)); err != nil {
+		return nil, err
+	}
+	if rounds < 1 {
+		return nil, invalidf("a tournament needs at least one round")
+	}
+
+	t := &Tournament{
+		ID:      uuid.NewRandom().String(),
+		Game:    gameName,
+		AIs:     aiIDs,
+		Rounds:  rounds,
+		Options: options,
+		Created: time.Now(),
+	}
+	for i := 0; i < rounds; i++ {
+		for _, combination := range combinations(aiIDs, numPlayers) {
+			m := s.newMatch(gameName, combination, options, 0, t.ID)
+			if err := s.saveMatch(m); err != nil {
+				return nil, err
+			}
+			t.Matches = append(t.Matches, m.ID)
+		}
+	}
+
+	s.tournaments[t.ID] = t
+	return t, writeJSON(filepath.Join(s.dir, "tournaments", t.ID+".json"), t)
+}
+
+func (s *Server) checkAIs(gameName game.Name, aiIDs []string, num int) error {
+	if _, ok := game.Data[gameName]; !ok {
+		return invalidf("unknown game: %s", gameName)
+	}
+	if len(aiIDs) != num {
+		return invalidf("%s needs %d AIs, got %d", gameName, num, len(aiIDs))
+	}
+
+	for _, id := range aiIDs {
+		ai, ok := s.ais[id]
+		if !ok {
+			return invalidf("no such AI: %s", id)
+		}
+		if ai.Game != gameName {
+			return invalidf("AI %s plays %s, not %s", id, ai.Game, gameName)
+		}
+	}
+	return nil
+}
+
+// newMatch queues a new match, which the caller has to save.
+func (s *Server) newMatch(gameName game.Name, aiIDs []string, options game.Options, seed uint64, tournamentID string) *Match {
+	m := &Match{
+		ID:         uuid.NewRandom().String(),
+		Tournament: tournamentID,
+		Game:       gameName,
+		AIs:        aiIDs,
+		Options:    options,
+		Seed:       seed,
+		Status:     StatusQueued,
+		Created:    time.Now(),
+	}
+	s.matches[m.ID] = m
+	s.enqueue(m.ID)
+	return m
+}
+
+func (s *Server) enqueue(id string) {
+	s.queue = append(s.queue, id)
+	s.wake.Signal()
+}
+
+func (s *Server) AIs() []AI {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ais := []AI{}
+	for _, ai := range s.ais {
+		ais = append(ais, *ai)
+	}
+	sort.Slice(ais, func(i, j int) bool { return ais[i].Uploaded.Before(ais[j].Uploaded) })
+	return ais
+}
+
+func (s *Server) AI(id string) (AI, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ai, ok := s.ais[id]
+	if !ok {
+		return AI{}, false
+	}
+	return *ai, true
+}
+
+func (s *Server) Matches() []Match {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := []Match{}
+	for _, m := range s.matches {
+		matches = append(matches, *m)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Created.Before(matches[j].Created) })
+	return matches
+}
+
+func (s *Server) Match(id string) (Match, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[id]
+	if !ok {
+		return Match{}, false
+	}
+	return *m, true
+}
+
+// Events returns a finished match's events as seen through view, which is full, public or a player ID.
+func (s *Server) Events(id, view string) ([]LoggedEvent, error) {
+	m, ok := s.Match(id)
+	if !ok {
+		return nil, errNotFound
+	}
+	if m.Status != StatusDone {
+		return nil, invalidf("match %s is %s, its events are ready once it's done", id, m.Status)
+	}
+
+	eventsJSON, err := os.ReadFile(s.eventsPath(id))
+	if err != nil {
+		return nil, err
+	}
+	events := map[string][]LoggedEvent{}
+	if err := json.Unmarshal(eventsJSON, &events); err != nil {
+		return nil, err
+	}
+
+	viewed, ok := events[view]
+	if !ok {
+		return nil, invalidf("invalid view %q, want full, public or a player ID from 1 to %d", view, len(m.AIs))
+	}
+	return viewed, nil
+}
+
+// Stream streams the match live, only showing public events. Once it's finished, it's streamed all at
+// once from what was saved, still without anything revealed once it was over.
+func (s *Server) Stream(id string) (*game.Stream, error) {
+	s.mu.Lock()
+	m, ok := s.matches[id]
+	if !ok {
+		s.mu.Unlock()
+		return nil, errNotFound
+	}
+	if m.Status != StatusDone {
+		defer s.mu.Unlock()
+		return s.liveStream(m.ID, m.Game), nil
+	}
+
+	match := *m
+	players := s.players(m)
+	s.mu.Unlock()
+
+	events, err := s.Events(id, "public")
+	if err != nil {
+		return nil, err
+	}
+
+	decode, _ := factory.EventDecoder(match.Game)
+	stream := game.NewStream(decode)
+	stream.OnStart(match.Game, players)
+	for _, e := range events {
+		// Who was shown what isn't saved, but the public view is only ever what everyone was shown
+		// during the game, so nothing the Reveal option showed spectators afterwards gets in.
+		e.Show = map[game.PlayerID]bool{game.ShowAll: true}
+		stream.OnEvent(e.Event)
+	}
+
+	var matchErr error
+	if match.Error != "" {
+		matchErr = errors.New(match.Error)
+	}
+	stream.OnEnd(match.Places, match.Termination, matchErr)
+	stream.Close()
+
+	return stream, nil
+}
+
+// Replay makes a replay page of a finished match, from what was saved.
+func (s *Server) Replay(id string) (game.Replay, error) {
+	s.mu.Lock()
+	m, ok := s.matches[id]
+	if !ok {
+		s.mu.Unlock()
+		return game.Replay{}, errNotFound
+	}
+	match := *m
+	players := s.players(m)
+	s.mu.Unlock()
+
+	r := game.Replay{
+		Game:        match.Game,
+		Players:     players,
+		Places:      match.Places,
+		Termination: match.Termination,
+		Error:       match.Error,
+	}
+
+	// Each view with its label, as game.NewReplay does it
+	views := [][2]string{{"full", "Everything"}, {"public", "Spectator"}}
+	for _, p := range players {
+		views = append(views, [2]string{strconv.Itoa(int(p.ID)), fmt.Sprintf("As %s", p)})
+	}
+	for _, v := range views {
+		events, err := s.Events(id, v[0])
+		if err != nil {
+			return game.Replay{}, err
+		}
+
+		rv := game.ReplayView{View: v[0], Label: v[1], Events: []game.StreamedEvent{}}
+		for _, e := range events {
+			rv.Events = append(rv.Events, game.StreamedEvent{Event: e.Event, Text: e.Text})
+		}
+		r.Views = append(r.Views, rv)
+	}
+
+	return r, nil
+}
+
+// players returns the match's players, named after their AIs. It's called with s.mu held.
+func (s *Server) players(m *Match) []*game.Player {
+	players := []*game.Player{}
+	for i, aiID := range m.AIs {
+		players = append(players, &game.Player{ID: game.PlayerID(i + 1), Name: s.ais[aiID].Name})
+	}
+	return players
+}
+
+// liveStream returns the stream for a match that hasn't finished, making it the first time it's asked
+// for. It's called with s.mu held.
+func (s *Server) liveStream(id string, gameName game.Name) *game.Stream {
+	stream, ok := s.streams[id]
+	if !ok {
+		decode, _ := factory.EventDecoder(gameName)
+		stream = game.NewStream(decode)
+		s.streams[id] = stream
+	}
+	return stream
+}
+
+// closeStream lets anyone watching the match know it's over, or cut off, and forgets its stream.
+func (s *Server) closeStream(id string) {
+	s.mu.Lock()
+	stream := s.streams[id]
+	delete(s.streams, id)
+	s.mu.Unlock()
+
+	if stream != nil {
+		stream.Close()
+	}
+}
+
+func (s *Server) Tournaments() []Tournament {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournaments := []Tournament{}
+	for _, t := range s.tournaments {
+		tournaments = append(tournaments, *t)
+	}
+	sort.Slice(tournaments, func(i, j int) bool { return tournaments[i].Created.Before(tournaments[j].Created) })
+	return tournaments
+}
+
+func (s *Server) Tournament(id string) (Tournament, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tournaments[id]
+	if !ok {
+		return Tournament{}, false
+	}
+	return *t, true
+}
+
+// Standings totals up the tournament's finished matches, best mean rank first, and says whether
+// they're all finished.
+func (s *Server) Standings(t Tournament) ([]Standing, Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	standings := map[string]*Standing{}
+	for _, id := range t.AIs {
+		standings[id] = &Standing{AI: id, Name: s.ais[id].Name, Ranks: map[int]int{}}
+	}
+
+	status := StatusDone
+	for _, id := range t.Matches {
+		m := s.matches[id]
+		if m.Status != StatusDone {
+			status = StatusRunning
+			continue
+		}
+
+		if m.Error != "" {
+			for _, aiID := range m.AIs {
+				standings[aiID].Errors++
+			}
+		}
+		for _, place := range m.Places {
+			standing := standings[m.AIs[place.Player.ID-1]]
+			standing.Played++
+			standing.Ranks[place.Rank]++
+		}
+	}
+
+	sorted := []Standing{}
+	for _, id := range t.AIs {
+		standing := standings[id]
+		if standing.Played > 0 {
+			total := 0
+			for rank, count := range standing.Ranks {
+				total += rank * count
+			}
+			standing.MeanRank = float64(total) / float64(standing.Played)
+		}
+		sorted = append(sorted, *standing)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Played == 0 || sorted[j].Played == 0 {
+			return sorted[i].Played > sorted[j].Played
+		}
+		return sorted[i].MeanRank < sorted[j].MeanRank
+	})
+
+	return sorted, status
+}
+
+func (s *Server) sourcePath(aiID string) string {
+	return filepath.Join(s.dir, "ais", aiID+".go")
+}
+
+func (s *Server) eventsPath(matchID string) string {
+	return filepath.Join(s.dir, "events", matchID+".json")
+}
+
+func (s *Server) saveMatch(m *Match) error {
+	return writeJSON(filepath.Join(s.dir, "matches", m.ID+".json"), m)
+}
+
+// load reads back everything saved, queueing again the matches that hadn't finished.
+func (s *Server) load() error {
+	if err := readAll(filepath.Join(s.dir, "ais"), func(data []byte) error {
+		ai := &AI{}
+		if err := json.Unmarshal(data, ai); err != nil {
+			return err
+		}
+		s.ais[ai.ID] = ai
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := readAll(filepath.Join(s.dir, "tournaments"), func(data []byte) error {
+		t := &Tournament{}
+		if err := json.Unmarshal(data, t); err != nil {
+			return err
+		}
+		s.tournaments[t.ID] = t
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	unfinished := []*Match{}
+	if err := readAll(filepath.Join(s.dir, "matches"), func(data []byte) error {
+		m := &Match{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return err
+		}
+		s.matches[m.ID] = m
+		if m.Status != StatusDone {
+			unfinished = append(unfinished, m)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	sort.Slice(unfinished, func(i, j int) bool { return unfinished[i].Created.Before(unfinished[j].Created) })
+	for _, m := range unfinished {
+		m.Status = StatusQueued
+		m.Started = nil
+		s.queue = append(s.queue, m.ID)
+	}
+
+	return nil
+}
+
+func readAll(dir string, read func(data []byte) error) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := read(data); err != nil {
+			return fmt.Errorf("couldn't read %s: %s", path, err)
+		}
+	}
+	return nil
+}
+
+// writeJSON writes to the side and moves it over, so we never leave a half-written file.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// combinations returns every way of choosing n of the ids, keeping them in order.
+func combinations(ids []string, n int) [][]string {
+	if n == 0 {
+		return [][]string{{}}
+	}
+	if len(ids) < n {
+		return nil
+	}
+
+	result := [][]string{}
+	for _, rest := range combinations(ids[1:], n-1) {
+		result = append(result, append([]string{ids[0]}, rest...))
+	}
+	return append(result, combinations(ids[1:], n)...)
+}
+
+var errNotFound = errors.New("not found")
+
+// invalidError is for a request that can't be done as asked, as opposed to something going wrong here.
+type invalidError struct {
+	msg string
+}
+
+func (e invalidError) Error() string {
+	return e.msg
+}
+
+func invalidf(format string, a ...interface{}) error {
+	return invalidError{msg: fmt.Sprintf(format, a...)}
+}
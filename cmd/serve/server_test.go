@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/tictactoe"
+	"github.com/boardgamesai/games/tictactoe/ai/driver"
+)
+
+// firstSquareAI takes the first open square, so games are quick and don't need compiling.
+type firstSquareAI struct{}
+
+func (ai *firstSquareAI) GetMove(state driver.State) tictactoe.Move {
+	return state.Board.PossibleMoves()[0]
+}
+
+func newTestServer(t *testing.T, dir string) *Server {
+	s, err := NewServer(dir, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	s.build = func(gameName game.Name, path string) (string, error) {
+		return "", nil
+	}
+	s.runnable = func(gameName game.Name, path string) game.Runnable {
+		return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+			d := driver.New(&firstSquareAI{})
+			d.SetIO(in, out, io.Discard)
+			d.Run()
+		})
+	}
+	return s
+}
+
+func request(t *testing.T, s *Server, method, path string, body interface{}, response interface{}) int {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	default:
+		bodyJSON, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		reader = bytes.NewReader(bodyJSON)
+	}
+
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest(method, path, reader))
+	if response != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), response); err != nil {
+			t.Fatalf("%s %s: couldn't decode response %q: %s", method, path, w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
+
+func addAI(t *testing.T, s *Server, name string) string {
+	ai := AI{}
+	if status := request(t, s, "POST", "/ais?game=tictactoe&name="+name, "package main", &ai); status != http.StatusCreated {
+		t.Fatalf("Expected %d adding %s, got %d", http.StatusCreated, name, status)
+	}
+	return ai.ID
+}
+
+func waitForMatch(t *testing.T, s *Server, id string) Match {
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		m := Match{}
+		request(t, s, "GET", "/matches/"+id, nil, &m)
+		if m.Status == StatusDone {
+			return m
+		}
+	}
+
+	t.Fatalf("Match %s didn't finish", id)
+	return Match{}
+}
+
+func TestAddAI(t *testing.T) {
+	s := newTestServer(t, t.TempDir())
+	s.build = func(gameName game.Name, path string) (string, error) {
+		return "ai.go:1:1: expected 'package', found oops\n", nil
+	}
+
+	result := struct{ Error, Output string }{}
+	if status := request(t, s, "POST", "/ais?game=tictactoe&name=broken", "oops", &result); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected %d for code that doesn't compile, got %d", http.StatusUnprocessableEntity, status)
+	}
+	if !strings.Contains(result.Output, "expected 'package'") {
+		t.Errorf("Expected the compiler's output, got: %q", result.Output)
+	}
+
+	if status := request(t, s, "POST", "/ais?game=chess&name=deep", "package main", nil); status != http.StatusBadRequest {
+		t.Errorf("Expected %d for an unknown game, got %d", http.StatusBadRequest, status)
+	}
+	if status := request(t, s, "GET", "/ais/nope", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected %d for an unknown AI, got %d", http.StatusNotFound, status)
+	}
+
+	ais := []AI{}
+	request(t, s, "GET", "/ais", nil, &ais)
+	if len(ais) != 0 {
+		t.Errorf("Expected no AIs, got: %+v", ais)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s := newTestServer(t, t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	ids := []string{addAI(t, s, "first"), addAI(t, s, "second")}
+	options := game.Options{Seating: game.SeatingFixed}
+
+	if status := request(t, s, "POST", "/matches", MatchRequest{Game: game.TicTacToe, AIs: ids[:1]}, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected %d for too few AIs, got %d", http.StatusBadRequest, status)
+	}
+
+	m := Match{}
+	if status := request(t, s, "POST", "/matches", MatchRequest{Game: game.TicTacToe, AIs: ids, Options: options}, &m); status != http.StatusCreated {
+		t.Fatalf("Expected %d, got %d", http.StatusCreated, status)
+	}
+
+	// Taking the first open square each time, the first to move wins on the diagonal
+	m = waitForMatch(t, s, m.ID)
+	if m.Error != "" || len(m.Places) != 2 || m.Places[0].Player.Name != "first" || m.Places[0].Rank != 1 {
+		t.Errorf("Expected first to win, got: %+v", m)
+	}
+
+	events := []LoggedEvent{}
+	request(t, s, "GET", "/matches/"+m.ID+"/events?view=1", nil, &events)
+	if len(events) != 7 || events[0].Type != "move" || events[0].Text != "ID 1 plays [0,0]" {
+		t.Errorf("Expected the 7 moves, but not the hidden setup, got: %+v", events)
+	}
+	if status := request(t, s, "GET", "/matches/"+m.ID+"/events?view=3", nil, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected %d for a player who wasn't there, got %d", http.StatusBadRequest, status)
+	}
+
+	// And it can be played back in a browser
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/matches/"+m.ID+"/replay", nil))
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") || !strings.Contains(w.Body.String(), `"Label":"As first (1)"`) {
+		t.Errorf("Expected a replay page with each player's view, got %s: %s", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestMatchStream(t *testing.T) {
+	s := newTestServer(t, t.TempDir())
+	ids := []string{addAI(t, s, "first"), addAI(t, s, "second")}
+	m := Match{}
+	options := game.Options{Reveal: game.RevealAll}
+	request(t, s, "POST", "/matches", MatchRequest{Game: game.TicTacToe, AIs: ids, Options: options}, &m)
+
+	// Watching from before the match starts, we see it all live
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/matches/" + m.ID + "/stream")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	live, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, expected := range []string{"event: snapshot", "event: start", "event: event", "event: end", "event: close"} {
+		if !strings.Contains(string(live), expected) {
+			t.Errorf("Expected %q in the stream, got: %s", expected, live)
+		}
+	}
+
+	// Once it's over, it's all in the snapshot
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/matches/"+m.ID+"/stream", nil))
+	snapshot := strings.SplitN(w.Body.String(), "\n", 3)
+	if snapshot[0] != "event: snapshot" || !strings.Contains(snapshot[1], `"End":{`) {
+		t.Errorf("Expected a snapshot of the whole match, got: %s", w.Body.String())
+	}
+
+	// Just as it was live, without what was revealed once it was over
+	if strings.Count(snapshot[1], `"Type":"move"`) != 7 || strings.Contains(snapshot[1], `"Type":"setup"`) {
+		t.Errorf("Expected the 7 moves, but not the revealed setup, got: %s", snapshot[1])
+	}
+	if status := request(t, s, "GET", "/matches/nope/stream", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected %d for an unknown match, got %d", http.StatusNotFound, status)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	s := newTestServer(t, t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	ids := []string{}
+	for i := 1; i <= 3; i++ {
+		ids = append(ids, addAI(t, s, fmt.Sprintf("ai%d", i)))
+	}
+
+	tournament := Tournament{}
+	req := TournamentRequest{Game: game.TicTacToe, AIs: ids, Rounds: 2}
+	if status := request(t, s, "POST", "/tournaments", req, &tournament); status != http.StatusCreated {
+		t.Fatalf("Expected %d, got %d", http.StatusCreated, status)
+	}
+	if len(tournament.Matches) != 6 {
+		t.Fatalf("Expected 3 pairings twice over, got %d matches", len(tournament.Matches))
+	}
+
+	for _, id := range tournament.Matches {
+		waitForMatch(t, s, id)
+	}
+
+	status := TournamentStatus{}
+	request(t, s, "GET", "/tournaments/"+tournament.ID, nil, &status)
+	if status.Status != StatusDone || len(status.Standings) != 3 {
+		t.Fatalf("Expected a finished tournament with 3 standings, got: %+v", status)
+	}
+	for _, standing := range status.Standings {
+		if standing.Played != 4 || standing.Ranks[1]+standing.Ranks[2] != 4 {
+			t.Errorf("Expected 4 games played, got: %+v", standing)
+		}
+	}
+	if status.Standings[0].MeanRank > status.Standings[2].MeanRank {
+		t.Errorf("Expected the best mean rank first, got: %+v", status.Standings)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestServer(t, dir)
+	ids := []string{addAI(t, s, "first"), addAI(t, s, "second")}
+
+	// Nothing's playing matches yet, so it's still queued when we restart
+	m := Match{}
+	request(t, s, "POST", "/matches", MatchRequest{Game: game.TicTacToe, AIs: ids}, &m)
+
+	s = newTestServer(t, dir)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	if m = waitForMatch(t, s, m.ID); len(m.Places) != 2 {
+		t.Errorf("Expected the match to be played after restarting, got: %+v", m)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	combos := combinations([]string{"a", "b", "c", "d"}, 2)
+	expected := "[[a b] [a c] [a d] [b c] [b d] [c d]]"
+	if fmt.Sprint(combos) != expected {
+		t.Errorf("Expected %s, got %s", expected, combos)
+	}
+
+	if combos = combinations([]string{"a", "b", "c", "d"}, 4); len(combos) != 1 {
+		t.Errorf("Expected one combination of all four, got %s", combos)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/boardgamesai/games/game"
 )
 
 // Board is 7 wide by 6 high with [0,0] in the lower left and [6,5] in the top right
@@ -170,3 +172,8 @@ func GetBoardFromString(s string) *Board {
 
 	return &b
 }
+
+// Hash fingerprints the board, so a driver can check its copy against ours.
+func (b *Board) Hash() string {
+	return game.Hash(b)
+}
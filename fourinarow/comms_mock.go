@@ -1,5 +1,11 @@
 package fourinarow
 
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
 type CommsMock struct {
 	moves map[int][]int
 	index int
@@ -12,11 +18,11 @@ func NewCommsMock(moves map[int][]int) *CommsMock {
 	}
 }
 
-func (c *CommsMock) Setup(p *Player, other *Player) error {
+func (c *CommsMock) Setup(ctx context.Context, p *Player, other *Player, start *Board) error {
 	return nil
 }
 
-func (c *CommsMock) GetMove(p *Player) (Move, error) {
+func (c *CommsMock) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error) {
 	if p.Order == 1 {
 		c.index++
 	}
@@ -24,5 +30,9 @@ func (c *CommsMock) GetMove(p *Player) (Move, error) {
 	move := Move{
 		Col: c.moves[p.Order][c.index],
 	}
-	return move, nil
+	return move, game.Concession{}, nil
+}
+
+func (c *CommsMock) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return nil
 }
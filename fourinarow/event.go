@@ -1,6 +1,7 @@
 package fourinarow
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -18,7 +19,8 @@ type EventSetupPlayer struct {
 }
 
 type EventSetup struct {
-	Players []EventSetupPlayer
+	Players       []EventSetupPlayer
+	StartPosition string `json:",omitempty"`
 }
 
 func (e EventSetup) String() string {
@@ -26,7 +28,12 @@ func (e EventSetup) String() string {
 	for _, p := range e.Players {
 		players = append(players, fmt.Sprintf("%+v", p))
 	}
-	return "Setup " + strings.Join(players, ", ")
+
+	s := "Setup " + strings.Join(players, ", ")
+	if e.StartPosition != "" {
+		s += " from position " + e.StartPosition
+	}
+	return s
 }
 
 type Coords struct {
@@ -44,3 +51,23 @@ type EventMove struct {
 func (e EventMove) String() string {
 	return fmt.Sprintf("ID %d plays %s", e.ID, e.Move)
 }
+
+// DecodeEvent turns a logged event back into the type it was logged as, e.g. for displaying it.
+func DecodeEvent(e game.Event) fmt.Stringer {
+	var eStr fmt.Stringer
+
+	switch e.Type {
+	case EventTypeSetup:
+		ce := EventSetup{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeMove:
+		ce := EventMove{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	default:
+		eStr = game.CommonEvent(e)
+	}
+
+	return eStr
+}
@@ -1,7 +1,7 @@
 package fourinarow
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/boardgamesai/games/game"
@@ -20,28 +20,55 @@ func New() *Game {
 }
 
 func (g *Game) Play() error {
+	return g.PlayContext(context.Background())
+}
+
+// PlayContext plays the game until it's over, or until ctx is done, in which case the game is aborted.
+func (g *Game) PlayContext(ctx context.Context) error {
+	return g.Finish(ctx, g.play(ctx, nil))
+}
+
+func (g *Game) play(ctx context.Context, s *game.Snapshot) (err error) {
 	// Wipe out any previous state
 	g.reset()
-	g.shufflePlayers()
+
+	playerTurn := 0
+	if s == nil {
+		g.seatPlayers()
+		if start, turn := g.startPosition(); start != nil {
+			g.Board = start
+			playerTurn = turn
+		}
+	} else {
+		var err error
+		if playerTurn, err = g.restore(s); err != nil {
+			return err
+		}
+	}
+	g.Start()
 
 	// We need to write down our setup
 	setupEvent := EventSetup{
-		Players: []EventSetupPlayer{},
+		Players:       []EventSetupPlayer{},
+		StartPosition: g.StartPosition(),
 	}
 
+	// Players need to know where we're starting from, even when resuming.
+	start, _ := g.startPosition()
+
 	// Launch the player processes
 	for _, player := range g.Players {
 		defer player.CleanUp()
 		defer g.SetOutput(player.ID, player)
 
 		// This copies files to a tmp dir, runs it, and sends a heartbeat message to verify.
-		err := player.Run()
+		err := player.Run(ctx)
 		if err != nil {
 			return fmt.Errorf("player %s failed to run, err: %s", player, err)
 		}
 
 		// This initializes the game state for this player.
-		err = g.Comms.Setup(player, g.otherPlayer(player))
+		err = g.Comms.Setup(ctx, player, g.otherPlayer(player), start)
 		if err != nil {
 			return fmt.Errorf("player %s failed to setup, err: %s", player, err)
 		}
@@ -54,13 +81,31 @@ func (g *Game) Play() error {
 		setupEvent.Players = append(setupEvent.Players, esp)
 	}
 
-	g.EventLog.AddNone(setupEvent)
+	if s == nil {
+		g.AddNone(setupEvent)
+	}
+
+	// Let everyone know how it went before they're shut down.
+	defer func() {
+		g.GameOver(ctx, err, func(ctx context.Context, p *Player, termination game.Termination) error {
+			return g.Comms.GameOver(ctx, p, termination, g.Places())
+		})
+	}()
+
+	// Everyone warms up before the first move, one at a time
+	for _, player := range g.Players {
+		if err := g.WarmUp(ctx, player); err != nil {
+			g.setWinner(g.otherPlayer(player))
+			return err
+		}
+	}
 
 	// Game is over when someone wins or board is filled
-	playerTurn := 0
 	for !g.Board.IsFull() {
+		g.checkpoint(playerTurn)
+
 		player := g.Players[playerTurn]
-		move, err := g.Comms.GetMove(player)
+		move, concession, err := g.Comms.GetMove(ctx, player, g.Board.Hash(), g.legalMoves(player), g.playerState(player))
 		if err != nil {
 			g.setWinner(g.otherPlayer(player))
 			switch e := err.(type) {
@@ -74,6 +119,14 @@ func (g *Game) Play() error {
 			return err
 		}
 
+		// Resigning or accepting a draw ends the game instead of a move
+		if over, err := g.Concede(player, concession); over {
+			if err != nil {
+				g.setWinner(g.otherPlayer(player))
+			}
+			return err
+		}
+
 		row, err := g.Board.ApplyMove(player.Order, move)
 		e := EventMove{
 			ID:   player.ID,
@@ -84,7 +137,7 @@ func (g *Game) Play() error {
 		if hasWinner {
 			e.WinCoords = winCoords
 		}
-		g.EventLog.AddAll(e)
+		g.AddAll(e)
 
 		if err != nil {
 			g.setWinner(g.otherPlayer(player))
@@ -100,7 +153,20 @@ func (g *Game) Play() error {
 			break
 		}
 
+		// Out of plies with moves still to make, so it's a draw
+		if g.AddPly() && !g.Board.IsFull() {
+			g.setWinner(nil)
+			g.Adjudicate(game.EventAdjudication{Reason: g.PlyCapReason()})
+			break
+		}
+
 		playerTurn = util.Increment(playerTurn, 0, 1)
+
+		// Let the player who just moved keep thinking on their opponent's time
+		if p, err := g.Ponder(ctx, []*Player{g.otherPlayer(g.Players[playerTurn])}); err != nil {
+			g.setWinner(g.otherPlayer(p))
+			return err
+		}
 	}
 
 	if len(g.Places()) == 0 {
@@ -112,23 +178,16 @@ func (g *Game) Play() error {
 }
 
 func (g *Game) Events() []fmt.Stringer {
-	events := make([]fmt.Stringer, len(g.EventLog))
-
-	for i, event := range g.EventLog {
-		var eStr fmt.Stringer
+	return g.EventsFor(game.ViewFull)
+}
 
-		switch event.Type {
-		case EventTypeSetup:
-			e := EventSetup{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeMove:
-			e := EventMove{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		}
+// EventsFor describes the events seen through v.
+func (g *Game) EventsFor(v game.View) []fmt.Stringer {
+	rawEvents := g.RawEventsFor(v)
+	events := make([]fmt.Stringer, len(rawEvents))
 
-		events[i] = eStr
+	for i, event := range rawEvents {
+		events[i] = DecodeEvent(event)
 	}
 
 	return events
@@ -160,14 +219,40 @@ func (g *Game) setWinner(p *Player) {
 	g.SetPlaces(places)
 }
 
-func (g *Game) shufflePlayers() {
-	util.Shuffle(g.Players)
+func (g *Game) seatPlayers() {
+	g.SeatPlayers()
+	g.assignSeats()
+}
 
+// assignSeats sets up each player according to their place in the turn order.
+func (g *Game) assignSeats() {
 	for i := 0; i < 2; i++ {
 		g.Players[i].Order = i + 1
 	}
 }
 
+// playerState is sent along with the move request to players who asked for the full state.
+func (g *Game) playerState(p *Player) *PlayerState {
+	if !p.FullState {
+		return nil
+	}
+	return &PlayerState{
+		ID:       p.ID,
+		Order:    p.Order,
+		Opponent: g.otherPlayer(p),
+		Board:    g.Board,
+	}
+}
+
+// legalMoves is sent along with the move request to players who asked for it. It's never nil for them,
+// so having no legal moves can't be mistaken for not having asked.
+func (g *Game) legalMoves(p *Player) []Move {
+	if !p.LegalMoves {
+		return nil
+	}
+	return append([]Move{}, g.Board.PossibleMoves()...)
+}
+
 func (g *Game) otherPlayer(player *Player) *Player {
 	if g.Players[0] == player {
 		return g.Players[1]
@@ -0,0 +1,68 @@
+package human
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boardgamesai/games/fourinarow"
+	"github.com/boardgamesai/games/fourinarow/ai/driver"
+	"github.com/boardgamesai/games/game"
+)
+
+// Human lets a person play Four-in-a-Row from the terminal, typing the column to drop into, like "d".
+type Human struct {
+	t          *game.Terminal
+	concession game.Concession // Typed along with, or instead of, the last move
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetMove(state driver.State) fourinarow.Move {
+	h.t.ShowEvents(state.NewEvents, fourinarow.DecodeEvent)
+	h.t.Printf("\n%s\nYou're player %d. Legal moves:", render(state.Board), state.Order)
+	for _, m := range state.Board.PossibleMoves() {
+		h.t.Printf(" %c", 'a'+m.Col)
+	}
+	h.t.Printf("\n")
+
+	move := fourinarow.Move{}
+	h.concession = h.t.AskMove("Your move (e.g. d): ", state.DrawOffered(), true, func(answer string) error {
+		if len(answer) != 1 || !strings.Contains("abcdefg", answer) {
+			return fmt.Errorf("no such column: %q", answer)
+		}
+
+		move = fourinarow.Move{Col: int(answer[0] - 'a')}
+		if err := state.Board.IsValidMove(move); err != nil {
+			return fmt.Errorf("can't move there: %s", err)
+		}
+		return nil
+	})
+
+	return move
+}
+
+// Concession goes out with the move, so typing "resign" or "offer" does what it says.
+func (h *Human) Concession() game.Concession {
+	return h.concession
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, fourinarow.DecodeEvent)
+	h.t.Printf("\n%s", render(state.Board))
+}
+
+func render(b *fourinarow.Board) string {
+	return game.RenderGrid(7, 6, func(col, row int) string {
+		if b[col][row] == fourinarow.Empty {
+			return "."
+		}
+		return fmt.Sprintf("%d", b[col][row])
+	})
+}
@@ -0,0 +1,66 @@
+package fourinarow
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/util"
+)
+
+//go:generate go run ../cmd/openings -n 50 -plies 4 -seed 1 -o openings.txt fourinarow
+
+//go:embed openings.txt
+var bundledOpenings string
+
+// Openings returns the bundled suite of openings, made from random legal playouts.
+func (g *Game) Openings() []game.Opening {
+	// It's embedded, so there's nothing to go wrong reading it
+	openings, _ := game.ReadOpenings(strings.NewReader(bundledOpenings))
+	return openings
+}
+
+// RandomOpening drops discs in random columns from an empty board for the given number of plies,
+// and returns the position it ends up in.
+func (g *Game) RandomOpening(r *util.Rand, plies int) (string, error) {
+	// A playout can end the game early, in which case we try another
+	for tries := 0; tries < 100; tries++ {
+		b := &Board{}
+		order := 1
+
+		for ply := 0; ply < plies; ply++ {
+			moves := b.PossibleMoves()
+			if len(moves) == 0 {
+				break
+			}
+
+			b.ApplyMove(order, moves[r.IntBetween(0, len(moves)-1)])
+			order = 3 - order
+		}
+
+		if hasWinner, _ := b.HasWinner(); !hasWinner && !b.IsFull() {
+			return formatPosition(b, order), nil
+		}
+	}
+
+	return "", fmt.Errorf("couldn't find an opening of %d plies that doesn't end the game", plies)
+}
+
+// formatPosition writes out a board in the format parsePosition reads.
+func formatPosition(b *Board, order int) string {
+	rows := []string{}
+	for row := 5; row >= 0; row-- {
+		cells := ""
+		for col := 0; col < 7; col++ {
+			if b[col][row] == Empty {
+				cells += "."
+			} else {
+				cells += fmt.Sprintf("%d", b[col][row])
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	return fmt.Sprintf("%s %d", strings.Join(rows, "/"), order)
+}
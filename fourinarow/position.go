@@ -0,0 +1,74 @@
+package fourinarow
+
+import (
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// SetPosition has the game start from a custom position rather than an empty board, or from the
+// usual empty board again if position is "". Rows go from top to bottom, with "." for an empty
+// space and "1" or "2" for each player's discs, followed by the order of whoever moves next,
+// e.g. "......./......./......./......./...2.../..11... 2".
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the board the game starts from, or nil for the usual empty one,
+// along with the index into Players of whoever moves first.
+func (g *Game) startPosition() (*Board, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	b, order, _ := parsePosition(g.StartPosition())
+	return b, order - 1
+}
+
+func parsePosition(position string) (*Board, int, error) {
+	rows, fields, err := game.SplitPosition(position, 7, 6)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(fields) != 1 || (fields[0] != "1" && fields[0] != "2") {
+		return nil, 0, game.PositionError(position, "expected 1 or 2 to move after the board")
+	}
+	order := int(fields[0][0] - '0')
+
+	b := Board{}
+	for i, row := range rows {
+		for j, cell := range row {
+			switch cell {
+			case '.':
+			case '1', '2':
+				b[j][5-i] = int(cell - '0')
+			default:
+				return nil, 0, game.PositionError(position, fmt.Sprintf("unknown space %q", cell))
+			}
+		}
+	}
+
+	// Discs drop to the bottom, so there can't be any gaps underneath one.
+	for col := 0; col <= 6; col++ {
+		for row := 1; row <= 5; row++ {
+			if b[col][row] != 0 && b[col][row-1] == 0 {
+				return nil, 0, game.PositionError(position, fmt.Sprintf("disc floating in column %d", col+1))
+			}
+		}
+	}
+
+	if hasWinner, _ := b.HasWinner(); hasWinner || b.IsFull() {
+		return nil, 0, game.PositionError(position, "the game is already over")
+	}
+
+	return &b, order, nil
+}
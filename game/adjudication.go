@@ -0,0 +1,82 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const EventTypeAdjudication = "adjudication"
+
+// AdjudicationScore is what a player had on whatever the game was decided on.
+type AdjudicationScore struct {
+	ID    PlayerID
+	Score int
+}
+
+// EventAdjudication is logged when the engine decides a game instead of letting it be played out.
+type EventAdjudication struct {
+	Reason string              // Why the game was stopped, e.g. "queens partitioned"
+	Basis  string              `json:",omitempty"` // What it was decided on, e.g. "territory", if anything
+	Scores []AdjudicationScore `json:",omitempty"`
+	Msg    string              `json:",omitempty"` // How a tie on the scores was broken, if it was
+	Result string              // Who won, or that it's a draw
+}
+
+func (e EventAdjudication) String() string {
+	s := fmt.Sprintf("adjudicated (%s)", e.Reason)
+	if e.Basis != "" {
+		scores := []string{}
+		for _, score := range e.Scores {
+			scores = append(scores, fmt.Sprintf("ID %d has %d", score.ID, score.Score))
+		}
+		s += fmt.Sprintf(" on %s: %s", e.Basis, strings.Join(scores, ", "))
+	}
+	if e.Msg != "" {
+		s += ", " + e.Msg
+	}
+	return s + ": " + e.Result
+}
+
+// AddPly counts a move toward the MaxPlies option, and says whether the game has now reached it.
+func (g *Game[P, B, C]) AddPly() bool {
+	g.plies++
+	return g.PlyCapReached()
+}
+
+// PlyCapReached says whether the game has made as many moves as the MaxPlies option allows, for games
+// that can only stop at certain points, like the end of a round.
+func (g *Game[P, B, C]) PlyCapReached() bool {
+	return g.options.MaxPlies > 0 && g.plies >= g.options.MaxPlies
+}
+
+// Plies is how many moves have been counted toward the MaxPlies option so far.
+func (g *Game[P, B, C]) Plies() int {
+	return g.plies
+}
+
+// PlyCapReason is the Reason for adjudicating a game that reached the MaxPlies option.
+func (g *Game[P, B, C]) PlyCapReason() string {
+	return fmt.Sprintf("ply cap of %d reached", g.options.MaxPlies)
+}
+
+// Adjudicate ends the game with the places already set, logging e to explain the decision to everyone.
+func (g *Game[P, B, C]) Adjudicate(e EventAdjudication) {
+	winners := []string{}
+	for _, place := range g.places {
+		if place.Rank == 1 {
+			winners = append(winners, fmt.Sprintf("ID %d", place.Player.ID))
+		}
+	}
+
+	switch {
+	case len(winners) == len(g.places):
+		e.Result = "draw"
+	case len(winners) == 1:
+		e.Result = winners[0] + " wins"
+	default:
+		e.Result = strings.Join(winners, " and ") + " tie for first"
+	}
+
+	g.AddAll(e)
+	g.endedBy = TerminationAdjudicated
+}
@@ -11,20 +11,46 @@ import (
 )
 
 type AIDriver struct {
-	stdin *bufio.Reader
+	stdin         *bufio.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+	annotation    *Annotation
+	stopPondering func() interface{} // Set while the AI's pondering, returns what it panicked with if it did
 }
 
 func (d *AIDriver) Setup() {
+	// Now grab stdio, unless we were given something else, or told to connect to the engine instead.
+	if d.stdin == nil {
+		link, err := ConnectRemoteFromEnv()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if link != nil {
+			d.SetIO(link, link, markerStripper{os.Stderr})
+		} else {
+			d.SetIO(os.Stdin, os.Stdout, os.Stderr)
+		}
+	}
+
 	// First thing we do upon launch is let our invoker know we started up okay.
 	// There could be Go compile-time issues preventing us from getting here.
-	fmt.Println("OK")
+	fmt.Fprintln(d.stderr, LogMarker)
+	fmt.Fprintln(d.stdout, "OK")
+}
 
-	// Now grab stdio, we need it for reading input later.
-	d.stdin = bufio.NewReader(os.Stdin)
+// SetIO has the driver talk to the engine over in and out instead of stdin and stdout, and log to
+// errOut, for running it in the same process as the game. It has to be called before Run.
+func (d *AIDriver) SetIO(in io.Reader, out, errOut io.Writer) {
+	d.stdin = bufio.NewReader(in)
+	d.stdout = out
+	d.stderr = errOut
 }
 
 func (d *AIDriver) GetNextMessage() (Message, error) {
 	mJSON, err := d.stdin.ReadBytes('\n')
+	d.StopPondering()
 	if err != nil && err != io.EOF {
 		return Message{}, err
 	}
@@ -36,16 +62,24 @@ func (d *AIDriver) GetNextMessage() (Message, error) {
 
 func (d *AIDriver) PrintResponse(data []byte) error {
 	m := MessageResponse{
-		Data: data,
+		Data:       data,
+		Annotation: d.annotation,
 	}
+	d.annotation = nil
 
 	return d.doPrint(m)
 }
 
+// Annotate sends a along with the next response, which should be the move it's about.
+func (d *AIDriver) Annotate(a *Annotation) {
+	d.annotation = a
+}
+
 func (d *AIDriver) PrintErrorResponse(err *DQError) error {
 	m := MessageResponse{
 		Err: err,
 	}
+	d.annotation = nil
 	return d.doPrint(m)
 }
 
@@ -61,6 +95,20 @@ func (d *AIDriver) HandlePanic(id PlayerID) {
 	}
 }
 
+// CheckHash compares the hash sent with a move request to the hash of the driver's own state, so if
+// they don't match we say so instead of playing on a wrong board. An empty expected hash isn't checked.
+func (d *AIDriver) CheckHash(id PlayerID, expected, actual string) *DQError {
+	if expected == "" || expected == actual {
+		return nil
+	}
+
+	return &DQError{
+		ID:   id,
+		Type: DQTypeDesync,
+		Msg:  fmt.Sprintf("driver state hash %s doesn't match engine's %s", actual, expected),
+	}
+}
+
 func (d *AIDriver) doPrint(m MessageResponse) error {
 	messageJSON, err := json.Marshal(m)
 	if err != nil {
@@ -68,8 +116,11 @@ func (d *AIDriver) doPrint(m MessageResponse) error {
 		return err
 	}
 
+	// Anything logged from here on is for the next message.
+	fmt.Fprintln(d.stderr, LogMarker)
+
 	// Need to strip any newlines since we use them to denote EOF when reading
-	fmt.Println(strings.ReplaceAll(string(messageJSON), "\n", " "))
+	fmt.Fprintln(d.stdout, strings.ReplaceAll(string(messageJSON), "\n", " "))
 	return nil
 }
 
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const EventTypeAnnotation = "annotation"
+
+// Annotation is what an AI can optionally say about its move: how it rates the position, how deep it
+// looked, the line it expects and anything else it wants to note. It's sent alongside the move in
+// MessageResponse, and none of it is checked. In the Go drivers, an AI sends one by implementing
+// Annotation() *game.Annotation, which is called right after each move; returning nil says nothing.
+type Annotation struct {
+	Eval    *float64 `json:",omitempty"` // From the AI's own point of view, on whatever scale it likes
+	Depth   int      `json:",omitempty"`
+	PV      []string `json:",omitempty"` // Principal variation, the moves it expects next, in any notation
+	Comment string   `json:",omitempty"`
+}
+
+func (a Annotation) String() string {
+	parts := []string{}
+	if a.Eval != nil {
+		parts = append(parts, fmt.Sprintf("eval %g", *a.Eval))
+	}
+	if a.Depth != 0 {
+		parts = append(parts, fmt.Sprintf("depth %d", a.Depth))
+	}
+	if len(a.PV) > 0 {
+		parts = append(parts, "pv "+strings.Join(a.PV, " "))
+	}
+	if a.Comment != "" {
+		parts = append(parts, fmt.Sprintf("%q", a.Comment))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EventAnnotation is logged just before the move it came with. Only the player who sent it is shown it
+// while the game is going on, and its opponents never are, even once the game is over. It shows up in
+// the full view, and in the spectators' revealed view if the Reveal option is RevealAll.
+type EventAnnotation struct {
+	ID PlayerID
+	Annotation
+}
+
+func (e EventAnnotation) String() string {
+	return fmt.Sprintf("ID %d notes %s", e.ID, e.Annotation)
+}
+
+// Annotator is implemented by Runnables that pass along the annotation, if any, that came with the
+// player's last response.
+type Annotator interface {
+	LastAnnotation() *Annotation
+}
+
+// annotation logs the annotation that came with the player's last response, if there was one.
+func (c *Comms) annotation(p *Player) {
+	a, ok := p.Runnable.(Annotator)
+	if !ok || a.LastAnnotation() == nil {
+		return
+	}
+
+	c.add(EventAnnotation{ID: p.ID, Annotation: *a.LastAnnotation()}, []PlayerID{p.ID})
+}
+
+// private says whether an event is only ever for the player it was shown to, whatever the Reveal option,
+// apart from spectators with RevealAll.
+func (e Event) private() bool {
+	return e.Type == EventTypeAnnotation
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+type annotatingRunnable struct {
+	RunnablePlayerMock
+	annotation *Annotation
+}
+
+func (r *annotatingRunnable) LastAnnotation() *Annotation {
+	return r.annotation
+}
+
+func TestAnnotation(t *testing.T) {
+	l := &EventLog{}
+	c := Comms{EventLog: l}
+	eval := 0.5
+	r := &annotatingRunnable{annotation: &Annotation{Eval: &eval, Depth: 3, Comment: "looks good"}}
+	p1 := &Player{ID: 1, Runnable: r}
+	p2 := &Player{ID: 2, Runnable: &RunnablePlayerMock{}}
+
+	if _, err := c.SendMessage(context.Background(), p1, MessageGameOver{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := c.SendMessage(context.Background(), p2, MessageGameOver{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(*l) != 1 || (*l)[0].Type != EventTypeAnnotation {
+		t.Fatalf("Expected one annotation to be logged, got: %+v", *l)
+	}
+
+	tests := []struct {
+		reveal   Reveal
+		view     View
+		expected string
+	}{
+		{"", ViewFull, "annotation"},
+		{"", ViewPlayer(1), "annotation"},
+		{"", ViewPlayer(2), ""},
+		{RevealPlayers, ViewRevealed(2), ""},
+		{RevealAll, ViewRevealed(2), ""},
+		{RevealPlayers, ViewRevealed(ShowAll), ""},
+		{RevealAll, ViewRevealed(ShowAll), "annotation"},
+	}
+
+	for _, test := range tests {
+		if types := eventTypes(l.View(test.view, test.reveal)); types != test.expected {
+			t.Errorf("Reveal %q, view %+v: expected %q, got: %q", test.reveal, test.view, test.expected, types)
+		}
+	}
+
+	if revealed := l.RevealedTo(2, RevealAll); len(revealed) != 0 {
+		t.Errorf("Expected the annotation not to be revealed to the opponent, got: %+v", revealed)
+	}
+	messages := []Message{{Type: "move", Data: []byte(`{"NewEvents":[{"Type":"annotation","Data":{"ID":1}}]}`)}}
+	if leaks := CheckMessages(2, messages, auditTestKnowledge{}); len(leaks) != 1 {
+		t.Errorf("Expected an opponent's annotation to be a leak, got: %+v", leaks)
+	}
+}
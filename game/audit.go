@@ -0,0 +1,187 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Knowledge declares what each player in a game with hidden information may know. It's written from
+// the rules rather than from how the game logs events, so Audit can catch the two disagreeing.
+type Knowledge interface {
+	// MayKnow says whether the player may be shown the event while the game is going on.
+	MayKnow(id PlayerID, e Event) bool
+
+	// CheckMessage looks at the fields of a message other than its events, given every event the player
+	// has been sent so far including this message's. It returns a Leak, with Field and Msg filled in,
+	// for anything the player couldn't have known from those events.
+	CheckMessage(id PlayerID, m Message, known []Event) []Leak
+}
+
+// Auditable is implemented by games that declare what their players may know.
+type Auditable interface {
+	Knowledge() Knowledge
+}
+
+// Leak is something a player was sent that they weren't supposed to know.
+type Leak struct {
+	Player      PlayerID
+	Message     int    // Index of the message in what the player was sent
+	MessageType string // e.g. "play"
+	Field       string // The message field it turned up in, e.g. "NewEvents"
+	Event       *Event `json:",omitempty"` // The offending event, if it was one
+	Msg         string `json:",omitempty"`
+}
+
+func (l Leak) String() string {
+	s := fmt.Sprintf("P%d message %d (%s) %s", l.Player, l.Message, l.MessageType, l.Field)
+	if l.Event != nil {
+		s += fmt.Sprintf(": %s %s", l.Event.Type, l.Event.Data)
+	}
+	if l.Msg != "" {
+		s += ": " + l.Msg
+	}
+	return s
+}
+
+// RecordingRunnable wraps a player's Runnable, keeping a copy of every message they're sent.
+type RecordingRunnable struct {
+	Runnable
+	mutex    sync.Mutex
+	messages []Message
+}
+
+func NewRecordingRunnable(r Runnable) *RecordingRunnable {
+	return &RecordingRunnable{
+		Runnable: r,
+	}
+}
+
+func (r *RecordingRunnable) SendMessage(ctx context.Context, message interface{}) ([]byte, error) {
+	r.record(message)
+	return r.Runnable.SendMessage(ctx, message)
+}
+
+func (r *RecordingRunnable) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	r.record(message)
+	return r.Runnable.SendMessageNoResponse(ctx, message)
+}
+
+// LastAnnotation passes along the wrapped Runnable's annotation, so recording doesn't lose it.
+func (r *RecordingRunnable) LastAnnotation() *Annotation {
+	if a, ok := r.Runnable.(Annotator); ok {
+		return a.LastAnnotation()
+	}
+	return nil
+}
+
+// Log passes along the wrapped Runnable's log, the same way.
+func (r *RecordingRunnable) Log() PlayerLog {
+	if lc, ok := r.Runnable.(LogCapturer); ok {
+		return lc.Log()
+	}
+	return plainLog(r.Runnable.Stderr())
+}
+
+// Messages returns what the player has been sent so far, as it went over the wire.
+func (r *RecordingRunnable) Messages() []Message {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Message{}, r.messages...)
+}
+
+func (r *RecordingRunnable) record(message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return // The player gets the same error when it's sent
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.messages = append(r.messages, Message{
+		Type: MessageType(message),
+		Data: data,
+	})
+}
+
+// Audit plays a game with every player's messages recorded, then checks them against k. Events revealed
+// once the game is over aren't checked, since the Reveal option decides who sees those.
+func Audit(ctx context.Context, g Playable, k Knowledge) ([]Leak, error) {
+	players := g.GetPlayers()
+	recorders := map[PlayerID]*RecordingRunnable{}
+	for _, p := range players {
+		r := NewRecordingRunnable(p.Runnable)
+		recorders[p.ID] = r
+		p.Runnable = r
+	}
+	defer func() {
+		for _, p := range players {
+			p.Runnable = recorders[p.ID].Runnable
+		}
+	}()
+
+	// A leak is still a leak if the game ends early, so check what was sent either way.
+	err := g.PlayContext(ctx)
+
+	leaks := []Leak{}
+	for _, p := range players {
+		leaks = append(leaks, CheckMessages(p.ID, recorders[p.ID].Messages(), k)...)
+	}
+
+	return leaks, err
+}
+
+// CheckMessages checks everything one player was sent against k, in the order they were sent it.
+func CheckMessages(id PlayerID, messages []Message, k Knowledge) []Leak {
+	leaks := []Leak{}
+	known := []Event{}
+
+	for i, m := range messages {
+		events := struct {
+			NewEvents []Event
+		}{}
+		if err := json.Unmarshal(m.Data, &events); err != nil {
+			leaks = append(leaks, Leak{
+				Player:      id,
+				Message:     i,
+				MessageType: m.Type,
+				Msg:         fmt.Sprintf("couldn't decode message: %s", err),
+			})
+			continue
+		}
+
+		for _, e := range events.NewEvents {
+			if !k.MayKnow(id, e) || !mayKnowAnnotation(id, e) {
+				leaks = append(leaks, Leak{
+					Player:      id,
+					Message:     i,
+					MessageType: m.Type,
+					Field:       "NewEvents",
+					Event:       &e,
+				})
+			}
+			known = append(known, e)
+		}
+
+		for _, leak := range k.CheckMessage(id, m, known) {
+			leak.Player = id
+			leak.Message = i
+			leak.MessageType = m.Type
+			leaks = append(leaks, leak)
+		}
+	}
+
+	return leaks
+}
+
+// mayKnowAnnotation checks that a player is only ever shown their own annotations, whatever the game.
+func mayKnowAnnotation(id PlayerID, e Event) bool {
+	if e.Type != EventTypeAnnotation {
+		return true
+	}
+
+	annotation := EventAnnotation{}
+	json.Unmarshal(e.Data, &annotation)
+	return annotation.ID == id
+}
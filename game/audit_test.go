@@ -0,0 +1,67 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type auditTestMessage struct {
+	NewEvents []Event
+}
+
+// auditTestKnowledge lets everyone see test1 events, and only player 2 see test2 ones.
+type auditTestKnowledge struct{}
+
+func (k auditTestKnowledge) MayKnow(id PlayerID, e Event) bool {
+	return e.Type == "test1" || (e.Type == "test2" && id == 2)
+}
+
+func (k auditTestKnowledge) CheckMessage(id PlayerID, m Message, known []Event) []Leak {
+	return nil
+}
+
+func TestCheckMessages(t *testing.T) {
+	l, err := getEventLog()
+	if err != nil {
+		t.Fatalf("Adding events returned error: %s", err)
+	}
+
+	// The log shows test3 to player 1 by mistake
+	tests := []struct {
+		id     PlayerID
+		leaked []string
+	}{
+		{1, []string{"test3"}},
+		{2, []string{}},
+	}
+
+	for _, test := range tests {
+		r := NewRecordingRunnable(&RunnablePlayerMock{})
+		if _, err := r.SendMessage(context.Background(), auditTestMessage{NewEvents: l.NewForPlayer(test.id)}); err != nil {
+			t.Fatalf("Sending message returned error: %s", err)
+		}
+
+		messages := r.Messages()
+		if len(messages) != 1 || messages[0].Type != "audittestmessage" {
+			t.Fatalf("Player %d: expected one recorded audittestmessage, got: %+v", test.id, messages)
+		}
+
+		leaks := CheckMessages(test.id, messages, auditTestKnowledge{})
+		if len(leaks) != len(test.leaked) {
+			t.Fatalf("Player %d: expected %d leaks, got: %+v", test.id, len(test.leaked), leaks)
+		}
+		for i, leak := range leaks {
+			if leak.Player != test.id || leak.Field != "NewEvents" || leak.Event == nil || leak.Event.Type != test.leaked[i] {
+				t.Errorf("Player %d: expected leak of %s in NewEvents, got: %s", test.id, test.leaked[i], leak)
+			}
+		}
+	}
+}
+
+func TestCheckMessagesUndecodable(t *testing.T) {
+	messages := []Message{{Type: "bad", Data: json.RawMessage(`"not an object"`)}}
+	if leaks := CheckMessages(1, messages, auditTestKnowledge{}); len(leaks) != 1 {
+		t.Errorf("Expected a leak for an undecodable message, got: %+v", leaks)
+	}
+}
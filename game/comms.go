@@ -1,9 +1,96 @@
 package game
 
+import (
+	"context"
+	"time"
+)
+
 type Comms struct {
-	EventLog *EventLog
+	EventLog  *EventLog
+	observers *[]Observer // The game's, so observers added after the comms are made are told too
+	revealed  *Reveal     // What the game's revealed now it's over
+}
+
+// BaseComms returns comms that log to the game's event log and let its observers know what's sent,
+// for each game's own comms to build on.
+func (g *Game[P, B, C]) BaseComms() Comms {
+	return Comms{
+		EventLog:  &g.EventLog,
+		observers: &g.observers,
+		revealed:  &g.revealed,
+	}
 }
 
 func (c *Comms) NewEvents(playerID PlayerID) []Event {
 	return c.EventLog.NewForPlayer(playerID)
 }
+
+// SendMessage sends a message to the player and returns their response, letting any observers
+// know about the request and how long the response took.
+func (c *Comms) SendMessage(ctx context.Context, p *Player, message interface{}) ([]byte, error) {
+	messageType := MessageType(message)
+	for _, o := range c.gameObservers() {
+		o.OnRequest(p.ID, messageType)
+	}
+
+	start := time.Now()
+	response, err := p.SendMessage(ctx, message)
+	elapsed := time.Since(start)
+
+	for _, o := range c.gameObservers() {
+		o.OnResponse(p.ID, messageType, elapsed, err)
+	}
+
+	if err == nil {
+		c.annotation(p)
+	}
+
+	return response, err
+}
+
+func (c *Comms) SendMessageNoResponse(ctx context.Context, p *Player, message interface{}) error {
+	messageType := MessageType(message)
+	for _, o := range c.gameObservers() {
+		o.OnRequest(p.ID, messageType)
+	}
+
+	start := time.Now()
+	err := p.SendMessageNoResponse(ctx, message)
+	elapsed := time.Since(start)
+
+	for _, o := range c.gameObservers() {
+		o.OnResponse(p.ID, messageType, elapsed, err)
+	}
+
+	return err
+}
+
+// SendGameOver tells the player how the game ended, along with anything hidden from them that's now been revealed.
+func (c *Comms) SendGameOver(ctx context.Context, p *Player, termination Termination, places []Place) error {
+	message := MessageGameOver{
+		Termination: termination,
+		Places:      places,
+		NewEvents:   c.NewEvents(p.ID),
+		Revealed:    c.EventLog.RevealedTo(p.ID, c.gameRevealed()),
+	}
+	return c.SendMessageNoResponse(ctx, p, message)
+}
+
+// add logs an event shown to the given players, letting the observers know.
+func (c *Comms) add(event interface{}, playerIDs []PlayerID) error {
+	return addEvent(c.EventLog, c.gameObservers(), event, playerIDs)
+}
+
+func (c *Comms) gameObservers() []Observer {
+	if c.observers == nil {
+		return nil
+	}
+	return *c.observers
+}
+
+func (c *Comms) gameRevealed() Reveal {
+	if c.revealed == nil {
+		return ""
+	}
+	return *c.revealed
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	EventTypeResign    = "resign"
+	EventTypeDrawOffer = "drawoffer"
+	EventTypeDraw      = "draw"
+)
+
+// EventResign is logged when a player gives up.
+type EventResign struct {
+	ID PlayerID
+}
+
+func (e EventResign) String() string {
+	return fmt.Sprintf("ID %d resigns", e.ID)
+}
+
+// EventDrawOffer is logged when a player offers a draw along with their move.
+type EventDrawOffer struct {
+	ID PlayerID
+}
+
+func (e EventDrawOffer) String() string {
+	return fmt.Sprintf("ID %d offers a draw", e.ID)
+}
+
+// EventDraw is logged when a player accepts their opponent's draw offer.
+type EventDraw struct {
+	ID PlayerID // Who accepted
+}
+
+func (e EventDraw) String() string {
+	return fmt.Sprintf("ID %d accepts the draw", e.ID)
+}
+
+// Concession is what a player in a two-player game can send besides a move. It goes in the same object
+// as the move, so for example {"Resign": true} is a valid response to any move request. In the Go drivers,
+// an AI sends one by implementing Concession() too, which is called right after each move.
+type Concession struct {
+	Resign     bool `json:",omitempty"` // Give up instead of moving
+	OfferDraw  bool `json:",omitempty"` // Offer a draw along with this move, in games that can tie
+	AcceptDraw bool `json:",omitempty"` // Accept the draw the opponent offered with their last move, instead of moving
+}
+
+// Concede handles a concession from the player whose turn it is. It returns true if the game is over,
+// with the places set, or if the concession wasn't allowed, in which case there's also a DQError.
+// A draw offer only lasts until the opponent's next turn, and moving instead of accepting declines it.
+func (g *Game[P, B, C]) Concede(p P, c Concession) (bool, error) {
+	player := p.BasePlayer()
+	var opponent *Player
+	for _, other := range g.Players {
+		if other.BasePlayer().ID != player.ID {
+			opponent = other.BasePlayer()
+		}
+	}
+
+	offeredBy := g.drawOffer
+	g.drawOffer = 0
+
+	switch {
+	case c.Resign:
+		g.AddAll(EventResign{ID: player.ID})
+		g.SetPlaces([]Place{
+			{Player: *opponent, Rank: 1},
+			{Player: *player, Rank: 2},
+		})
+		g.endedBy = TerminationResign
+		return true, nil
+
+	case c.AcceptDraw:
+		if offeredBy != opponent.ID {
+			return true, DQError{
+				ID:   player.ID,
+				Type: DQTypeInvalidMove,
+				Msg:  "accepted a draw that wasn't offered",
+			}
+		}
+
+		g.AddAll(EventDraw{ID: player.ID})
+		places := []Place{}
+		for _, other := range g.Players {
+			places = append(places, Place{Player: *other.BasePlayer(), Rank: 1, Tie: true})
+		}
+		g.SetPlaces(places)
+		g.endedBy = TerminationDraw
+		return true, nil
+
+	case c.OfferDraw:
+		if !g.MetaData().HasTies {
+			return true, DQError{
+				ID:   player.ID,
+				Type: DQTypeInvalidMove,
+				Msg:  fmt.Sprintf("offered a draw, but %s can't be drawn", g.MetaData().DisplayName),
+			}
+		}
+
+		g.drawOffer = player.ID
+		g.AddAll(EventDrawOffer{ID: player.ID})
+	}
+
+	return false, nil
+}
+
+// DrawOffered says whether the opponent offered a draw with the move they just made, which can be
+// accepted by responding with AcceptDraw.
+func (s *State) DrawOffered() bool {
+	for _, e := range s.NewEvents {
+		if e.Type != EventTypeDrawOffer {
+			continue
+		}
+
+		offer := EventDrawOffer{}
+		if err := json.Unmarshal(e.Data, &offer); err == nil && offer.ID != s.ID {
+			return true
+		}
+	}
+	return false
+}
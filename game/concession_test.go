@@ -0,0 +1,67 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func getConcessionGame(name Name) *Game[*seatingTestPlayer, struct{}, struct{}] {
+	g := &Game[*seatingTestPlayer, struct{}, struct{}]{Name: name}
+	for i := 1; i <= 2; i++ {
+		g.Players = append(g.Players, &seatingTestPlayer{Player{ID: PlayerID(i)}})
+	}
+	return g
+}
+
+func TestConcedeOfferDraw(t *testing.T) {
+	g := getConcessionGame(TicTacToe)
+	over, err := g.Concede(g.Players[0], Concession{OfferDraw: true})
+	if over || err != nil {
+		t.Fatalf("Expected the game to go on after a draw offer, got over: %t err: %v", over, err)
+	}
+	if g.drawOffer != 1 {
+		t.Errorf("Expected an open draw offer from 1, got: %d", g.drawOffer)
+	}
+
+	over, err = g.Concede(g.Players[1], Concession{AcceptDraw: true})
+	if !over || err != nil {
+		t.Fatalf("Expected the draw to end the game, got over: %t err: %v", over, err)
+	}
+	if g.terminationFor(nil) != TerminationDraw {
+		t.Errorf("Expected draw termination, got: %s", g.terminationFor(nil))
+	}
+	for _, place := range g.Places() {
+		if place.Rank != 1 || !place.Tie {
+			t.Errorf("Expected a tie, got: %+v", g.Places())
+		}
+	}
+
+	g = getConcessionGame(Amazons)
+	over, err = g.Concede(g.Players[0], Concession{OfferDraw: true})
+	if !over || err == nil {
+		t.Errorf("Expected a draw offer in a game without ties to be an error, got over: %t err: %v", over, err)
+	}
+}
+
+func TestDrawOffered(t *testing.T) {
+	offer := func(id PlayerID) Event {
+		data, _ := json.Marshal(EventDrawOffer{ID: id})
+		return Event{Type: EventTypeDrawOffer, Data: data}
+	}
+
+	s := State{ID: 1}
+	s.AddEvents([]Event{offer(2)})
+	if !s.DrawOffered() {
+		t.Errorf("Expected opponent's draw offer to be seen")
+	}
+
+	s.AddEvents([]Event{offer(1)})
+	if s.DrawOffered() {
+		t.Errorf("Expected own draw offer not to count")
+	}
+
+	s.AddEvents([]Event{})
+	if s.DrawOffered() {
+		t.Errorf("Expected draw offer to only last one turn")
+	}
+}
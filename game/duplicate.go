@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+)
+
+// DuplicateResult is how the games played from one seed went, one with the players rotated into each seat.
+type DuplicateResult struct {
+	Seed  uint64
+	Games []SeatedGame
+}
+
+// Ranks is the total of a player's finishing ranks over the games, lower being better.
+func (r DuplicateResult) Ranks(id PlayerID) int {
+	ranks := 0
+	for _, g := range r.Games {
+		for _, place := range g.Places {
+			if place.Player.ID == id {
+				ranks += place.Rank
+			}
+		}
+	}
+
+	return ranks
+}
+
+// Score is the total of a player's scores over the games, for games that keep score.
+func (r DuplicateResult) Score(id PlayerID) int {
+	score := 0
+	for _, g := range r.Games {
+		for _, place := range g.Places {
+			if place.Player.ID == id {
+				score += place.Score
+			}
+		}
+	}
+
+	return score
+}
+
+// PlayDuplicate plays the game from each seed once for every seat, moving the players one seat along each time,
+// so that each of them gets the deals or rolls the seed gives every seat. That takes most of the luck out of
+// comparing them. Playing stops early if ctx is done, returning the results so far along with the error.
+// The game is left with a new seed for each game, and players seated as the Seating option says.
+func PlayDuplicate(ctx context.Context, g Playable, seeds []uint64) ([]DuplicateResult, error) {
+	players := g.GetPlayers()
+	ids := []PlayerID{}
+	for _, p := range players {
+		ids = append(ids, p.ID)
+	}
+
+	defer g.SetSeats(nil)
+	defer g.SetSeed(0)
+
+	results := []DuplicateResult{}
+	for _, seed := range seeds {
+		g.SetSeed(seed)
+
+		result := DuplicateResult{Seed: seed}
+		for i := range ids {
+			seats := append(append([]PlayerID{}, ids[i:]...), ids[:i]...)
+			played, err := playSeated(ctx, g, seats)
+			if err != nil {
+				return results, err
+			}
+			result.Games = append(result.Games, played)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
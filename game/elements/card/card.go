@@ -1,5 +1,7 @@
 package card
 
+import "fmt"
+
 type Suit string
 
 const (
@@ -64,6 +66,23 @@ func (c Card) String() string {
 	return string(c.Rank) + string(c.Suit)
 }
 
+// Parse is FromString for input we don't trust, returning an error if it isn't a real card.
+func Parse(s string) (Card, error) {
+	if len(s) != 2 {
+		return Card{}, fmt.Errorf("invalid card: %q", s)
+	}
+
+	c := FromString(s)
+	if _, ok := suitMap[c.Suit]; !ok {
+		return Card{}, fmt.Errorf("invalid suit in card: %q", s)
+	}
+	if _, ok := rankMap[c.Rank]; !ok {
+		return Card{}, fmt.Errorf("invalid rank in card: %q", s)
+	}
+
+	return c, nil
+}
+
 // FromString takes input like "4C" or "JH" and returns a Card
 func FromString(s string) Card {
 	return Card{
@@ -36,6 +36,24 @@ func NewDeck[C Cardable](cards []C) *Deck[C] {
 }
 
 func NewStandardDeck() StandardDeck {
+	d := StandardDeck{
+		Deck: NewDeck(standardCards()),
+	}
+	return d
+}
+
+// NewStandardDeckWith is NewStandardDeck, but shuffled using r, so the same r always gives the same deck.
+func NewStandardDeckWith(r *util.Rand) StandardDeck {
+	d := StandardDeck{
+		Deck: &Deck[Card]{
+			cards: standardCards(),
+		},
+	}
+	d.ShuffleWith(r)
+	return d
+}
+
+func standardCards() []Card {
 	cards := make([]Card, 52)
 
 	i := 0
@@ -46,10 +64,7 @@ func NewStandardDeck() StandardDeck {
 		}
 	}
 
-	d := StandardDeck{
-		Deck: NewDeck(cards),
-	}
-	return d
+	return cards
 }
 
 func (d *Deck[C]) Shuffle() {
@@ -57,6 +72,25 @@ func (d *Deck[C]) Shuffle() {
 	d.current = 0
 }
 
+func (d *Deck[C]) ShuffleWith(r *util.Rand) {
+	util.ShuffleWith(r, d.cards)
+	d.current = 0
+}
+
+// Cards returns the whole deck in its current order, including any cards already dealt.
+func (d *Deck[C]) Cards() []C {
+	cards := make([]C, len(d.cards))
+	copy(cards, d.cards)
+	return cards
+}
+
+// SetCards puts the deck in the given order, with nothing dealt yet.
+func (d *Deck[C]) SetCards(cards []C) {
+	d.cards = make([]C, len(cards))
+	copy(d.cards, cards)
+	d.current = 0
+}
+
 func (d *Deck[C]) Count() int {
 	return len(d.cards) - d.current
 }
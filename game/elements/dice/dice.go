@@ -46,6 +46,12 @@ func (d *Dice[D]) Roll() {
 	}
 }
 
+func (d *Dice[D]) RollWith(r *util.Rand) {
+	for i := 0; i < len(d.Values); i++ {
+		d.Values[i] = d.dieValues[r.IntBetween(0, len(d.dieValues)-1)]
+	}
+}
+
 func (d *Dice[D]) Add(value D) {
 	d.Values = append(d.Values, value)
 }
@@ -11,6 +11,8 @@ const (
 	DQTypeInvalidMove = DQType("badmove")
 	DQTypeTimeout     = DQType("timeout")
 	DQTypeRuntime     = DQType("runtime")
+	DQTypeDesync      = DQType("desync")     // The driver's board didn't match ours, which isn't the AI's fault
+	DQTypeDisconnect  = DQType("disconnect") // A remote player couldn't be reached
 )
 
 // DQError = DisqualifiedError
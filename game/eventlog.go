@@ -74,6 +74,17 @@ func (el *EventLog) NewForPlayer(playerID PlayerID) []Event {
 	return events
 }
 
+// HiddenFrom returns every event the player wasn't shown, in order, without marking anything seen.
+func (el *EventLog) HiddenFrom(playerID PlayerID) []Event {
+	events := []Event{}
+	for _, e := range *el {
+		if !(e.Show[ShowAll] || e.Show[playerID]) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
 func (el *EventLog) Clear() {
 	*el = []Event{}
 }
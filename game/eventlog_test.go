@@ -123,3 +123,34 @@ func checkLog(l *EventLog, ID PlayerID, expected []PlayerID) ([]Event, bool) {
 
 	return events, true
 }
+
+func TestHiddenFrom(t *testing.T) {
+	l, err := getEventLog()
+	if err != nil {
+		t.Fatalf("Adding events returned error: %s", err)
+	}
+
+	tests := []struct {
+		id       PlayerID
+		expected []string
+	}{
+		{1, []string{"test2"}},
+		{2, []string{"test3"}},
+		{3, []string{"test2", "test3"}},
+	}
+
+	for _, test := range tests {
+		types := []string{}
+		for _, e := range l.HiddenFrom(test.id) {
+			types = append(types, e.Type)
+		}
+		if strings.Join(types, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("Player %d: expected hidden %v, got: %v", test.id, test.expected, types)
+		}
+	}
+
+	// Looking at what's hidden doesn't count as seeing anything
+	if events := l.NewForPlayer(1); len(events) != 2 {
+		t.Errorf("Expected 2 new events for player 1, got: %d", len(events))
+	}
+}
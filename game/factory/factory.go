@@ -4,13 +4,20 @@ import (
 	"fmt"
 
 	"github.com/boardgamesai/games/amazons"
+	amazonshuman "github.com/boardgamesai/games/amazons/human"
 	"github.com/boardgamesai/games/fourinarow"
+	fourinarowhuman "github.com/boardgamesai/games/fourinarow/human"
 	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/hearts"
+	heartshuman "github.com/boardgamesai/games/hearts/human"
 	"github.com/boardgamesai/games/liarsdice"
+	liarsdicehuman "github.com/boardgamesai/games/liarsdice/human"
 	"github.com/boardgamesai/games/reversi"
+	reversihuman "github.com/boardgamesai/games/reversi/human"
 	"github.com/boardgamesai/games/tictactoe"
+	tictactoehuman "github.com/boardgamesai/games/tictactoe/human"
 	"github.com/boardgamesai/games/ulttictactoe"
+	ulttictactoehuman "github.com/boardgamesai/games/ulttictactoe/human"
 )
 
 func New(gameName game.Name) (game.Playable, error) {
@@ -38,3 +45,57 @@ func New(gameName game.Name) (game.Playable, error) {
 
 	return g, err
 }
+
+// NewHuman makes a Runnable for a person to play the game from the terminal t.
+func NewHuman(gameName game.Name, t *game.Terminal) (game.Runnable, error) {
+	var r game.Runnable
+	var err error
+
+	switch gameName {
+	case game.Amazons:
+		r = amazonshuman.New(t)
+	case game.FourInARow:
+		r = fourinarowhuman.New(t)
+	case game.LiarsDice:
+		r = liarsdicehuman.New(t)
+	case game.Hearts:
+		r = heartshuman.New(t)
+	case game.Reversi:
+		r = reversihuman.New(t)
+	case game.TicTacToe:
+		r = tictactoehuman.New(t)
+	case game.UltTicTacToe:
+		r = ulttictactoehuman.New(t)
+	default:
+		err = fmt.Errorf("unknown game: %s", gameName)
+	}
+
+	return r, err
+}
+
+// EventDecoder returns the game's DecodeEvent, which makes sense of its events, e.g. for a Stream.
+func EventDecoder(gameName game.Name) (func(e game.Event) fmt.Stringer, error) {
+	var decode func(e game.Event) fmt.Stringer
+	var err error
+
+	switch gameName {
+	case game.Amazons:
+		decode = amazons.DecodeEvent
+	case game.FourInARow:
+		decode = fourinarow.DecodeEvent
+	case game.LiarsDice:
+		decode = liarsdice.DecodeEvent
+	case game.Hearts:
+		decode = hearts.DecodeEvent
+	case game.Reversi:
+		decode = reversi.DecodeEvent
+	case game.TicTacToe:
+		decode = tictactoe.DecodeEvent
+	case game.UltTicTacToe:
+		decode = ulttictactoe.DecodeEvent
+	default:
+		err = fmt.Errorf("unknown game: %s", gameName)
+	}
+
+	return decode, err
+}
@@ -2,6 +2,8 @@ package game
 
 import (
 	"sort"
+
+	"github.com/boardgamesai/games/util"
 )
 
 type Game[P PlayerBaseable, B any, C any] struct {
@@ -10,14 +12,40 @@ type Game[P PlayerBaseable, B any, C any] struct {
 	Players []P
 	Comms   C
 	EventLog
-	output map[PlayerID]string
-	places []Place
+	output      map[PlayerID]PlayerLog
+	places      []Place
+	options     Options
+	substitutes []PlayerID // Players who were DQ'd and replaced by a fallback AI, in order
+	termination Termination
+	rng         *util.Rand
+	checkpoint  *Snapshot
+	position    string      // Custom starting position, which sticks around between games like options
+	seats       []PlayerID  // Fixed seat order, or nil to seat players as the Seating option says
+	seated      bool        // Whether players have been seated for a game yet, for rotating them
+	seed        uint64      // Random seed for each game, or 0 for a new one each time
+	endedBy     Termination // Set if a player resigned or agreed to a draw, or the engine adjudicated
+	drawOffer   PlayerID    // Who offered a draw with their last move, if anyone
+	plies       int         // Moves counted toward the MaxPlies option
+	observers   []Observer  // Who's following the games as they're played
+	revealed    Reveal      // What's been revealed now the game is over, or "" if it isn't
 }
 
 func (g *Game[P, B, C]) Reset() {
 	g.EventLog.Clear()
-	g.output = map[PlayerID]string{}
+	g.output = map[PlayerID]PlayerLog{}
 	g.places = []Place{}
+	g.substitutes = []PlayerID{}
+	g.termination = ""
+	g.endedBy = ""
+	g.plies = 0
+	g.drawOffer = 0
+	g.revealed = ""
+	seed := g.seed
+	if seed == 0 {
+		seed = util.RandSeed()
+	}
+	g.rng = util.NewRand(seed)
+	g.checkpoint = nil
 }
 
 func (g *Game[P, B, C]) GetPlayers() []*Player {
@@ -40,12 +68,37 @@ func (g *Game[P, B, C]) MetaData() MetaData {
 	return Data[g.Name]
 }
 
+func (g *Game[P, B, C]) Options() Options {
+	return g.options
+}
+
+func (g *Game[P, B, C]) SetOptions(options Options) {
+	g.options = options
+}
+
+// LoggedOutput is what the player logged, each line tagged with the message it was handling and when.
 func (g *Game[P, B, C]) LoggedOutput(id PlayerID) string {
+	return g.output[id].String()
+}
+
+// Log is what the player logged, line by line.
+func (g *Game[P, B, C]) Log(id PlayerID) PlayerLog {
 	return g.output[id]
 }
 
+// SetOutput keeps what the player logged once they're done. Runnables that don't split it up by message
+// have it all tagged as before the first one.
 func (g *Game[P, B, C]) SetOutput(id PlayerID, r Runnable) {
-	g.output[id] = r.Stderr()
+	// Games pass their own players, which only have the Runnable's methods in the Runnable interface.
+	if p, ok := r.(PlayerBaseable); ok && p.BasePlayer().Runnable != nil {
+		r = p.BasePlayer().Runnable
+	}
+
+	if lc, ok := r.(LogCapturer); ok {
+		g.output[id] = lc.Log()
+		return
+	}
+	g.output[id] = plainLog(r.Stderr())
 }
 
 func (g *Game[P, B, C]) RawEvents() EventLog {
@@ -58,7 +111,13 @@ func (g *Game[P, B, C]) Places() []Place {
 
 func (g *Game[P, B, C]) SetPlaces(places []Place) {
 	// Let's ensure these are sorted here
-	sort.Slice(places, func(i, j int) bool { return places[i].Rank < places[j].Rank })
+	sort.SliceStable(places, func(i, j int) bool { return places[i].Rank < places[j].Rank })
+
+	// Anyone who was DQ'd finishes behind everyone else, even if their fallback AI did well.
+	if len(g.substitutes) > 0 {
+		places = g.demoteSubstitutes(places)
+	}
+
 	g.places = places
 }
 
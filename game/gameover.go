@@ -0,0 +1,49 @@
+package game
+
+import (
+	"context"
+	"time"
+)
+
+// GameOverGracePeriod is how long a player has to handle MessageGameOver, e.g. to flush its logs
+// or save what it learned, before it's shut down.
+const GameOverGracePeriod = 2 * time.Second
+
+// GameOver tells each player still in the game how it ended, given the error from playing it, using send
+// to do the game-specific part. It's called once the game is over and before the players are cleaned up.
+// Nothing is sent if ctx is done, since the game was aborted and the players are being shut down. A player
+// DQ'd for taking too long isn't told either, since they could still be working on their last response.
+func (g *Game[P, B, C]) GameOver(ctx context.Context, err error, send func(ctx context.Context, p P, termination Termination) error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	// What players are told matches what they'll be able to see in the log afterwards
+	g.reveal()
+
+	termination := g.terminationFor(err)
+	for _, p := range g.Players {
+		// Their process is already gone
+		if g.IsSubstitute(p.BasePlayer().ID) {
+			continue
+		}
+		if timedOut(err, p.BasePlayer().ID) {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, GameOverGracePeriod)
+		send(sendCtx, p, termination) // The game's over, so there's nothing to do if this fails
+		cancel()
+	}
+}
+
+// timedOut says whether err is the player being DQ'd for not responding in time.
+func timedOut(err error, id PlayerID) bool {
+	switch e := err.(type) {
+	case DQError:
+		return e.ID == id && e.Type == DQTypeTimeout
+	case *DQError:
+		return e.ID == id && e.Type == DQTypeTimeout
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGameOver(t *testing.T) {
+	tests := []struct {
+		err         error
+		termination Termination
+		told        int
+	}{
+		{nil, TerminationNormal, 3},
+		{DQError{ID: 2}, TerminationDQ, 3},
+		{&DQError{ID: 2}, TerminationDQ, 3},
+		{&DQError{ID: 2, Type: DQTypeDesync}, TerminationError, 3},
+		{errors.New("oops"), TerminationError, 3},
+		{DQError{ID: 2, Type: DQTypeTimeout}, TerminationDQ, 2},
+		{&DQError{ID: 2, Type: DQTypeTimeout}, TerminationDQ, 2},
+	}
+
+	for _, test := range tests {
+		g := getSeatingGame()
+		g.substitutes = []PlayerID{3}
+
+		sent := map[PlayerID]Termination{}
+		g.GameOver(context.Background(), test.err, func(ctx context.Context, p *seatingTestPlayer, termination Termination) error {
+			if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > GameOverGracePeriod {
+				t.Errorf("Expected a grace period deadline, got: %v", deadline)
+			}
+			sent[p.ID] = termination
+			return nil
+		})
+
+		if len(sent) != test.told {
+			t.Errorf("Expected %d players told, got: %v", test.told, sent)
+		}
+		if _, ok := sent[3]; ok {
+			t.Errorf("Substitute was told the game is over")
+		}
+		if _, ok := sent[2]; ok && test.told == 2 {
+			t.Errorf("Player who timed out was told the game is over")
+		}
+		for id, termination := range sent {
+			if termination != test.termination {
+				t.Errorf("Player %d: expected %s, got: %s", id, test.termination, termination)
+			}
+		}
+	}
+}
+
+func TestGameOverAborted(t *testing.T) {
+	g := getSeatingGame()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g.GameOver(ctx, ctx.Err(), func(ctx context.Context, p *seatingTestPlayer, termination Termination) error {
+		t.Errorf("Player %d was told about an aborted game", p.ID)
+		return nil
+	})
+}
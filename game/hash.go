@@ -0,0 +1,21 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+)
+
+// Hash is a short fingerprint of v's JSON encoding, which is canonical for the arrays, structs and
+// sorted maps that boards are made of. Move requests carry one so drivers can tell when their copy
+// of the board has drifted from ours.
+func Hash(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	h := fnv.New64a()
+	h.Write(data)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
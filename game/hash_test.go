@@ -0,0 +1,41 @@
+package game
+
+import "testing"
+
+func TestHash(t *testing.T) {
+	board := [2][2]string{{"X", ""}, {"", "O"}}
+	same := [2][2]string{{"X", ""}, {"", "O"}}
+	other := [2][2]string{{"X", "O"}, {"", ""}}
+
+	if Hash(board) != Hash(same) {
+		t.Errorf("Expected equal boards to hash the same")
+	}
+	if Hash(board) == Hash(other) {
+		t.Errorf("Expected different boards to hash differently")
+	}
+	if len(Hash(board)) != 16 {
+		t.Errorf("Expected a 16-character hash, got: %s", Hash(board))
+	}
+
+	// Maps are encoded in key order, so the order they're built in doesn't matter
+	m1 := map[PlayerID]int{1: 5, 2: 3}
+	m2 := map[PlayerID]int{2: 3}
+	m2[1] = 5
+	if Hash(m1) != Hash(m2) {
+		t.Errorf("Expected equal maps to hash the same")
+	}
+}
+
+func TestCheckHash(t *testing.T) {
+	d := AIDriver{}
+
+	if err := d.CheckHash(1, "", "abc"); err != nil {
+		t.Errorf("Expected no error when there's no hash to check, got: %s", err)
+	}
+	if err := d.CheckHash(1, "abc", "abc"); err != nil {
+		t.Errorf("Expected no error for matching hashes, got: %s", err)
+	}
+	if err := d.CheckHash(1, "abc", "def"); err == nil || err.Type != DQTypeDesync || err.ID != 1 {
+		t.Errorf("Expected desync error for player 1, got: %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HumanRunnable is a Runnable for a person playing from the terminal. It runs the game's usual driver
+// in this process, over pipes instead of stdin and stdout, with an AI that asks the person for each
+// move, so they see the game just as an AI would. There's no time limit on their responses.
+type HumanRunnable struct {
+	run        func(in io.Reader, out io.Writer) // Runs the driver until the game's over
+	toDriver   io.Writer
+	fromDriver *bufio.Reader
+	annotation *Annotation // Whatever came with the last response
+}
+
+func NewHumanRunnable(run func(in io.Reader, out io.Writer)) *HumanRunnable {
+	return &HumanRunnable{
+		run: run,
+	}
+}
+
+func (h *HumanRunnable) Run(ctx context.Context) error {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	h.toDriver = inW
+	h.fromDriver = bufio.NewReader(outR)
+
+	go h.run(inR, outW)
+
+	response, err := h.exchange(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if string(response) != "OK" {
+		return fmt.Errorf("got non-OK response when launching player: %s", response)
+	}
+	return nil
+}
+
+// CleanUp leaves the driver be, since it's done once it's handled the game over message, and if the
+// game ended without one, it's waiting for a message that won't come.
+func (h *HumanRunnable) CleanUp() error {
+	return nil
+}
+
+func (h *HumanRunnable) SendMessage(ctx context.Context, message interface{}) ([]byte, error) {
+	h.annotation = nil
+
+	messageJSON, err := json.Marshal(&message)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	mJSON, err := json.Marshal(&Message{
+		Type: MessageType(message),
+		Data: messageJSON,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	response, err := h.exchange(ctx, append(mJSON, '\n'))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	mr := MessageResponse{}
+	if err := json.Unmarshal(response, &mr); err != nil {
+		return response, err
+	}
+	if mr.Err != nil {
+		return []byte{}, mr.Err
+	}
+
+	h.annotation = mr.Annotation
+	return mr.Data, nil
+}
+
+func (h *HumanRunnable) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	response, err := h.SendMessage(ctx, message)
+	if err != nil {
+		return err
+	}
+	if string(response) != "\"OK\"" { // Hack - this is JSON-encoded
+		return fmt.Errorf("got non-OK response: %s", response)
+	}
+
+	return nil
+}
+
+func (h *HumanRunnable) LastAnnotation() *Annotation {
+	return h.annotation
+}
+
+// Stderr is empty, since there's no separate process logging anything.
+func (h *HumanRunnable) Stderr() string {
+	return ""
+}
+
+// exchange writes line, if there is one, to the driver and waits as long as it takes for a line back.
+func (h *HumanRunnable) exchange(ctx context.Context, line []byte) ([]byte, error) {
+	type result struct {
+		response []byte
+		err      error
+	}
+	resultChan := make(chan result, 1)
+
+	go func() {
+		if line != nil {
+			if _, err := h.toDriver.Write(line); err != nil {
+				resultChan <- result{err: err}
+				return
+			}
+		}
+
+		response, err := h.fromDriver.ReadBytes('\n')
+		resultChan <- result{response: []byte(strings.TrimSpace(string(response))), err: err}
+	}()
+
+	select {
+	case r := <-resultChan:
+		return r.response, r.err
+	case <-ctx.Done():
+		return []byte{}, ctx.Err()
+	}
+}
+
+// Terminal is where a human player sees the game and types their moves.
+type Terminal struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func NewTerminal(in io.Reader, out io.Writer) *Terminal {
+	return &Terminal{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+func (t *Terminal) Printf(format string, a ...interface{}) {
+	fmt.Fprintf(t.out, format, a...)
+}
+
+// Ask shows the prompt and reads a line, asking again until accept takes it. It panics if there's no
+// more input, which disqualifies the player the same as an AI panicking.
+func (t *Terminal) Ask(prompt string, accept func(answer string) error) {
+	for {
+		t.Printf("%s", prompt)
+		answer, err := t.in.ReadString('\n')
+		if err != nil {
+			panic(fmt.Sprintf("no more input from the terminal: %s", err))
+		}
+
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			continue
+		}
+		if err := accept(answer); err != nil {
+			t.Printf("%s\n", err)
+			continue
+		}
+		return
+	}
+}
+
+// AskMove asks a player in a two-player game for their move, handling resigning and draws the same
+// way in every game: "resign", "accept" for a draw they were offered, or a move followed by "offer" to
+// offer a draw with it, if canDraw. Anything else is passed to parse, and the concession is returned.
+func (t *Terminal) AskMove(prompt string, drawOffered, canDraw bool, parse func(move string) error) Concession {
+	if drawOffered {
+		t.Printf("Your opponent offers a draw, type accept to take it.\n")
+	}
+
+	c := Concession{}
+	t.Ask(prompt, func(answer string) error {
+		c = Concession{}
+		fields := strings.Fields(strings.ToLower(answer))
+
+		switch {
+		case len(fields) == 1 && fields[0] == "resign":
+			c.Resign = true
+			return nil
+
+		case len(fields) == 1 && fields[0] == "accept":
+			if !drawOffered {
+				return fmt.Errorf("no draw was offered")
+			}
+			c.AcceptDraw = true
+			return nil
+
+		case len(fields) > 1 && fields[len(fields)-1] == "offer":
+			if !canDraw {
+				return fmt.Errorf("this game can't be drawn")
+			}
+			c.OfferDraw = true
+			fields = fields[:len(fields)-1]
+		}
+
+		return parse(strings.Join(fields, " "))
+	})
+
+	return c
+}
+
+// ShowEvents prints what's happened since the player last heard, using decode to make sense of the
+// game's own events.
+func (t *Terminal) ShowEvents(events []Event, decode func(e Event) fmt.Stringer) {
+	for _, e := range events {
+		if s := decode(e); s != nil {
+			t.Printf("* %s\n", s)
+		}
+	}
+}
+
+// ShowGameOver prints how the game ended.
+func (t *Terminal) ShowGameOver(gameOver MessageGameOver, decode func(e Event) fmt.Stringer) {
+	t.ShowEvents(gameOver.NewEvents, decode)
+
+	t.Printf("\nGame over (%s):\n", gameOver.Termination)
+	for _, place := range gameOver.Places {
+		tie := ""
+		if place.Tie {
+			tie = " (tie)"
+		}
+		t.Printf("%d.%s %s (ID: %d)\n", place.Rank, tie, place.Player.Name, place.Player.ID)
+	}
+}
+
+// Square names a board square the usual way, e.g. "b3" for column 1, row 2, counting from the bottom left.
+func Square(col, row int) string {
+	return fmt.Sprintf("%c%d", 'a'+col, row+1)
+}
+
+// ParseSquare is the reverse of Square, for a board with the given number of columns and rows.
+func ParseSquare(s string, cols, rows int) (int, int, error) {
+	s = strings.ToLower(s)
+
+	row := 0
+	if len(s) < 2 {
+		return 0, 0, fmt.Errorf("invalid square: %q", s)
+	}
+	if _, err := fmt.Sscanf(s[1:], "%d", &row); err != nil {
+		return 0, 0, fmt.Errorf("invalid square: %q", s)
+	}
+	col := int(s[0]) - 'a'
+	row--
+
+	// Comparing with Square also catches anything Sscanf let through, like "a01" or "a1x"
+	if col < 0 || col >= cols || row < 0 || row >= rows || Square(col, row) != s {
+		return 0, 0, fmt.Errorf("no such square: %q", s)
+	}
+	return col, row, nil
+}
+
+// RenderGrid draws a board with its rows numbered up the side and its columns lettered along the
+// bottom, so players can read off squares the way ParseSquare takes them. cell gives what's in each.
+func RenderGrid(cols, rows int, cell func(col, row int) string) string {
+	s := ""
+	for row := rows - 1; row >= 0; row-- {
+		s += fmt.Sprintf("%2d ", row+1)
+		for col := 0; col < cols; col++ {
+			s += " " + cell(col, row)
+		}
+		s += "\n"
+	}
+
+	s += "   "
+	for col := 0; col < cols; col++ {
+		s += fmt.Sprintf(" %c", 'a'+col)
+	}
+	return s + "\n"
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseSquare(t *testing.T) {
+	tests := []struct {
+		square   string
+		cols     int
+		rows     int
+		col      int
+		row      int
+		expected bool
+	}{
+		{"a1", 3, 3, 0, 0, true},
+		{"C2", 3, 3, 2, 1, true},
+		{"j10", 10, 10, 9, 9, true},
+		{"d4", 3, 3, 0, 0, false},
+		{"a0", 3, 3, 0, 0, false},
+		{"a01", 3, 3, 0, 0, false},
+		{"b", 3, 3, 0, 0, false},
+		{"2b", 3, 3, 0, 0, false},
+	}
+
+	for _, test := range tests {
+		col, row, err := ParseSquare(test.square, test.cols, test.rows)
+		if test.expected != (err == nil) || col != test.col || row != test.row {
+			t.Errorf("%q: expected %d,%d ok %t, got: %d,%d err %v", test.square, test.col, test.row, test.expected, col, row, err)
+		}
+		if err == nil && Square(col, row) != strings.ToLower(test.square) {
+			t.Errorf("%q: round trip gave %q", test.square, Square(col, row))
+		}
+	}
+}
+
+func TestRenderGrid(t *testing.T) {
+	expected := " 2  . X\n 1  O .\n    a b\n"
+
+	cells := map[[2]int]string{{0, 0}: "O", {1, 1}: "X"}
+	grid := RenderGrid(2, 2, func(col, row int) string {
+		if cell, ok := cells[[2]int{col, row}]; ok {
+			return cell
+		}
+		return "."
+	})
+	if grid != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, grid)
+	}
+}
+
+func TestAskMove(t *testing.T) {
+	tests := []struct {
+		input       string
+		drawOffered bool
+		canDraw     bool
+		expected    Concession
+		moves       []string
+	}{
+		{"b2\n", false, true, Concession{}, []string{"b2"}},
+		{"zz\nb2\n", false, true, Concession{}, []string{"zz", "b2"}},
+		{"Resign\n", false, true, Concession{Resign: true}, []string{}},
+		{"accept\nb2\n", false, true, Concession{}, []string{"b2"}},
+		{"accept\n", true, true, Concession{AcceptDraw: true}, []string{}},
+		{"b2 offer\n", false, true, Concession{OfferDraw: true}, []string{"b2"}},
+		{"b2 offer\nb2\n", false, false, Concession{}, []string{"b2"}},
+	}
+
+	for _, test := range tests {
+		term := NewTerminal(strings.NewReader(test.input), io.Discard)
+		moves := []string{}
+		c := term.AskMove("> ", test.drawOffered, test.canDraw, func(move string) error {
+			moves = append(moves, move)
+			if move != "b2" {
+				return errors.New("bad move")
+			}
+			return nil
+		})
+		if c != test.expected || strings.Join(moves, ",") != strings.Join(test.moves, ",") {
+			t.Errorf("%q: expected %+v %v, got: %+v %v", test.input, test.expected, test.moves, c, moves)
+		}
+	}
+}
+
+func TestHumanRunnable(t *testing.T) {
+	r := NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := AIDriver{}
+		d.SetIO(in, out, io.Discard)
+		d.Setup()
+
+		for {
+			message, _ := d.GetNextMessage()
+			if message.Type == "gameover" {
+				d.PrintResponse(d.OkJSON())
+				return
+			}
+			d.PrintErrorResponse(&DQError{Type: DQTypeInvalidMove, Msg: message.Type})
+		}
+	})
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err := r.SendMessage(context.Background(), MessagePonder{})
+	if dqErr, ok := err.(*DQError); !ok || dqErr.Msg != "ponder" {
+		t.Errorf("Expected the driver's error back, got: %v", err)
+	}
+	if err := r.SendMessageNoResponse(context.Background(), MessageGameOver{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// The driver's done, so nothing answers, but a cancelled context still gets us out
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.SendMessage(ctx, MessagePonder{}); err != context.Canceled {
+		t.Errorf("Expected the context's error, got: %v", err)
+	}
+}
+
+func TestHumanRunnableAnnotation(t *testing.T) {
+	r := NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := AIDriver{}
+		d.SetIO(in, out, io.Discard)
+		d.Setup()
+
+		// Annotate the first response, then fail the next
+		d.GetNextMessage()
+		d.Annotate(&Annotation{Depth: 2, Comment: "thinking"})
+		d.PrintResponse(d.OkJSON())
+
+		d.GetNextMessage()
+		d.PrintErrorResponse(&DQError{Type: DQTypeInvalidMove, Msg: "no"})
+	})
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var _ Annotator = r
+	if err := r.SendMessageNoResponse(context.Background(), MessagePonder{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if a := r.LastAnnotation(); a == nil || a.Depth != 2 || a.Comment != "thinking" {
+		t.Errorf("Expected the driver's annotation, got: %+v", a)
+	}
+
+	// A failed exchange leaves nothing over from the last one
+	if _, err := r.SendMessage(context.Background(), MessagePonder{}); err == nil {
+		t.Errorf("Expected the driver's error")
+	}
+	if a := r.LastAnnotation(); a != nil {
+		t.Errorf("Expected no annotation, got: %+v", a)
+	}
+}
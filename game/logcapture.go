@@ -0,0 +1,201 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLogCap is how many bytes of a player's logged output are kept, unless set otherwise.
+const DefaultLogCap = 1 << 20
+
+// logLineOverhead is what each line counts for against the cap besides its text, roughly what keeping
+// a LogLine costs, so a player logging nothing but empty lines can't run up memory.
+const logLineOverhead = 64
+
+// LogLine is one line a player logged, tagged with the message they were handling when they logged it.
+type LogLine struct {
+	Exchange int    // Which message it was, counting from 1, or 0 for before the first one, e.g. compiling
+	Message  string `json:",omitempty"` // The message's type, e.g. "move"
+	Time     time.Time
+	Text     string
+}
+
+func (l LogLine) String() string {
+	if l.Time.IsZero() {
+		return l.Text // Not tagged
+	}
+
+	tag := fmt.Sprintf("%d", l.Exchange)
+	if l.Message != "" {
+		tag += " " + l.Message
+	}
+	return fmt.Sprintf("[%s %s] %s", tag, l.Time.Format("15:04:05.000"), l.Text)
+}
+
+// PlayerLog is what a player logged, or the most recent part of it if it went over the cap.
+type PlayerLog struct {
+	Lines   []LogLine
+	Dropped int // Lines dropped from the start to stay under the cap
+}
+
+func (l PlayerLog) String() string {
+	lines := []string{}
+	if l.Dropped > 0 {
+		lines = append(lines, fmt.Sprintf("(%d earlier lines dropped)", l.Dropped))
+	}
+	for _, line := range l.Lines {
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ForExchange returns the lines logged while handling one message.
+func (l PlayerLog) ForExchange(exchange int) []LogLine {
+	lines := []LogLine{}
+	for _, line := range l.Lines {
+		if line.Exchange == exchange {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// plainLog splits up output from a Runnable that doesn't tag it, as if it was all from before the first message.
+func plainLog(output string) PlayerLog {
+	l := PlayerLog{}
+	if output == "" {
+		return l
+	}
+
+	for _, text := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		l.Lines = append(l.Lines, LogLine{Text: text})
+	}
+	return l
+}
+
+// LogCapturer is implemented by Runnables that keep what the player logged split up by message.
+type LogCapturer interface {
+	Log() PlayerLog
+}
+
+// LogMarker is written to stderr by the Go drivers just before each response. Stderr arrives separately
+// from responses, so it's how LogCapture knows which message a line was logged for.
+const LogMarker = "\x1eboardgamesai\x1e"
+
+// LogCapture is an io.Writer for a player's stderr, which splits it into lines tagged with the message
+// the player is handling. Once the lines add up to more than the cap, counting logLineOverhead for each,
+// the oldest ones are dropped, though the newest is always kept.
+// Without a LogMarker after each response, lines are tagged with the message most recently sent when
+// they arrive, which can be the next one for a line logged just before responding.
+type LogCapture struct {
+	mutex    sync.Mutex
+	limit    int
+	size     int
+	lines    []LogLine
+	dropped  int
+	partial  []byte   // The start of a line that hasn't ended yet
+	messages []string // The type of each message sent so far
+	markers  int      // LogMarkers seen so far
+}
+
+func NewLogCapture(limit int) *LogCapture {
+	return &LogCapture{
+		limit: limit,
+	}
+}
+
+func (c *LogCapture) Write(p []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.partial = append(c.partial, p...)
+	for {
+		i := bytes.IndexByte(c.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if line := string(c.partial[:i]); line == LogMarker {
+			c.markers++
+		} else {
+			c.add(line)
+		}
+		c.partial = c.partial[i+1:]
+	}
+
+	// A line that's over the cap on its own is cut short rather than held on to.
+	if len(c.partial) > c.limit {
+		c.add(string(c.partial))
+		c.partial = nil
+	}
+
+	return len(p), nil
+}
+
+// StartExchange is called as each message is sent, with its type.
+func (c *LogCapture) StartExchange(message string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.messages = append(c.messages, message)
+}
+
+func (c *LogCapture) Log() PlayerLog {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	l := PlayerLog{
+		Lines:   append([]LogLine{}, c.lines...),
+		Dropped: c.dropped,
+	}
+	if len(c.partial) > 0 {
+		l.Lines = append(l.Lines, c.line(string(c.partial)))
+	}
+
+	// Lines logged after responding but before the next message only find out what it was now.
+	for i, line := range l.Lines {
+		if line.Exchange > 0 && line.Exchange <= len(c.messages) {
+			l.Lines[i].Message = c.messages[line.Exchange-1]
+		}
+	}
+	return l
+}
+
+// Text is everything still kept, without the tags, as the player wrote it.
+func (c *LogCapture) Text() string {
+	lines := []string{}
+	for _, line := range c.Log().Lines {
+		lines = append(lines, line.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *LogCapture) add(text string) {
+	if len(text) > c.limit {
+		text = text[:c.limit]
+	}
+
+	c.lines = append(c.lines, c.line(text))
+	c.size += len(text) + logLineOverhead
+
+	for c.size > c.limit && len(c.lines) > 1 {
+		c.size -= len(c.lines[0].Text) + logLineOverhead
+		c.lines = c.lines[1:]
+		c.dropped++
+	}
+}
+
+func (c *LogCapture) line(text string) LogLine {
+	exchange := len(c.messages)
+	if c.markers > 0 {
+		exchange = c.markers
+	}
+
+	return LogLine{
+		Exchange: exchange,
+		Time:     time.Now(),
+		Text:     text,
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func logTexts(lines []LogLine) string {
+	texts := []string{}
+	for _, line := range lines {
+		texts = append(texts, fmt.Sprintf("%d:%s:%s", line.Exchange, line.Message, line.Text))
+	}
+	return strings.Join(texts, ",")
+}
+
+func TestLogCapture(t *testing.T) {
+	c := NewLogCapture(DefaultLogCap)
+	fmt.Fprintln(c, "compiling")
+	fmt.Fprintln(c, LogMarker)
+	c.StartExchange("setup")
+	fmt.Fprintln(c, "set up")
+	fmt.Fprintln(c, LogMarker)
+	c.StartExchange("move")
+
+	// Written in pieces, and arriving after the next message went out
+	fmt.Fprint(c, "thin")
+	c.StartExchange("move")
+	fmt.Fprint(c, "king\nstill ")
+	fmt.Fprintln(c, "thinking")
+	fmt.Fprintln(c, LogMarker)
+	fmt.Fprint(c, "after")
+
+	l := c.Log()
+	expected := "0::compiling,1:setup:set up,2:move:thinking,2:move:still thinking,3:move:after"
+	if texts := logTexts(l.Lines); texts != expected {
+		t.Errorf("Expected %s, got: %s", expected, texts)
+	}
+	if texts := logTexts(l.ForExchange(2)); texts != "2:move:thinking,2:move:still thinking" {
+		t.Errorf("Got the wrong lines for exchange 2: %s", texts)
+	}
+	if text := c.Text(); text != "compiling\nset up\nthinking\nstill thinking\nafter" {
+		t.Errorf("Got the wrong text: %q", text)
+	}
+}
+
+func TestLogCaptureWithoutMarkers(t *testing.T) {
+	c := NewLogCapture(DefaultLogCap)
+	c.StartExchange("setup")
+	fmt.Fprintln(c, "set up")
+	c.StartExchange("move")
+	fmt.Fprintln(c, "thinking")
+
+	if texts := logTexts(c.Log().Lines); texts != "1:setup:set up,2:move:thinking" {
+		t.Errorf("Got the wrong lines: %s", texts)
+	}
+}
+
+func TestLogCaptureCap(t *testing.T) {
+	c := NewLogCapture(10)
+	c.StartExchange("move")
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(c, "line %d\n", i)
+	}
+	fmt.Fprintln(c, strings.Repeat("x", 25))
+
+	l := c.Log()
+	if l.Dropped != 5 {
+		t.Errorf("Expected 5 lines dropped, got: %d", l.Dropped)
+	}
+	if texts := logTexts(l.Lines); texts != "1:move:xxxxxxxxxx" {
+		t.Errorf("Expected only the long line, cut short, got: %s", texts)
+	}
+	if !strings.HasPrefix(l.String(), "(5 earlier lines dropped)\n[1 move ") {
+		t.Errorf("Got the wrong string: %s", l)
+	}
+}
+
+func TestLogCaptureEmptyLines(t *testing.T) {
+	c := NewLogCapture(logLineOverhead * 10)
+	c.StartExchange("move")
+	fmt.Fprint(c, strings.Repeat("\n", 1000))
+
+	l := c.Log()
+	if len(l.Lines) != 10 || l.Dropped != 990 {
+		t.Errorf("Expected 10 empty lines kept and 990 dropped, got %d and %d", len(l.Lines), l.Dropped)
+	}
+}
+
+func TestSetLogCap(t *testing.T) {
+	p := NewRunnablePlayer("tictactoe", "random.go")
+	for _, limit := range []int{0, -1} {
+		if err := p.SetLogCap(limit); err == nil {
+			t.Errorf("Expected a log cap of %d to be rejected", limit)
+		}
+	}
+	if err := p.SetLogCap(10); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
@@ -8,6 +8,17 @@ type Message struct {
 }
 
 type MessageResponse struct {
-	Err  *DQError `json:",omitempty"`
-	Data json.RawMessage
+	Err        *DQError `json:",omitempty"`
+	Data       json.RawMessage
+	Annotation *Annotation `json:",omitempty"` // Optional notes on the move in Data
+}
+
+// MessageGameOver is the last message each player gets. Along with how the game ended, it has what
+// happened since they last heard from us, and unless the Reveal option says otherwise, everything they
+// weren't shown while it was going on, like other players' cards or dice.
+type MessageGameOver struct {
+	Termination Termination
+	Places      []Place
+	NewEvents   []Event
+	Revealed    []Event
 }
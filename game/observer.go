@@ -0,0 +1,78 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Observer follows a game live as it's played, e.g. to stream it to a UI, collect metrics,
+// or write out a transcript that survives a crash. Hooks are called synchronously from the
+// game loop, so they should return quickly. Embed NopObserver to only implement some of them.
+type Observer interface {
+	OnStart(name Name, players []*Player)
+	OnEvent(e Event) // e.Show holds who can see it, ShowAll meaning everyone
+	OnRequest(id PlayerID, messageType string)
+	OnResponse(id PlayerID, messageType string, elapsed time.Duration, err error)
+	OnEnd(places []Place, termination Termination, err error)
+}
+
+type NopObserver struct{}
+
+func (o NopObserver) OnStart(name Name, players []*Player)                                         {}
+func (o NopObserver) OnEvent(e Event)                                                              {}
+func (o NopObserver) OnRequest(id PlayerID, messageType string)                                    {}
+func (o NopObserver) OnResponse(id PlayerID, messageType string, elapsed time.Duration, err error) {}
+func (o NopObserver) OnEnd(places []Place, termination Termination, err error)                     {}
+
+// AddObserver has o follow the game, and any played after it.
+func (g *Game[P, B, C]) AddObserver(o Observer) {
+	g.observers = append(g.observers, o)
+}
+
+// Add logs an event shown to the given players, and lets the observers know about it. Events need to
+// be added through the game, rather than straight to its EventLog, for observers to see them.
+func (g *Game[P, B, C]) Add(event interface{}, playerIDs []PlayerID) error {
+	return addEvent(&g.EventLog, g.observers, event, playerIDs)
+}
+
+func (g *Game[P, B, C]) AddAll(event interface{}) error {
+	return g.Add(event, []PlayerID{ShowAll})
+}
+
+func (g *Game[P, B, C]) AddNone(event interface{}) error {
+	return g.Add(event, []PlayerID{})
+}
+
+func addEvent(el *EventLog, observers []Observer, event interface{}, playerIDs []PlayerID) error {
+	if err := el.Add(event, playerIDs); err != nil {
+		return err
+	}
+
+	e := (*el)[len(*el)-1]
+	for _, o := range observers {
+		o.OnEvent(e)
+	}
+	return nil
+}
+
+// Start tells observers the game is under way, once the players are in their final order.
+func (g *Game[P, B, C]) Start() {
+	players := g.GetPlayers()
+	for _, o := range g.observers {
+		o.OnStart(g.Name, players)
+	}
+}
+
+func (g *Game[P, B, C]) end(err error) {
+	g.reveal()
+	for _, o := range g.observers {
+		o.OnEnd(g.places, g.termination, err)
+	}
+}
+
+// MessageType is the wire name for a Message* struct, e.g. "move" for MessageMove.
+func MessageType(message interface{}) string {
+	messageType := reflect.TypeOf(message).Name()
+	return strings.ToLower(strings.TrimPrefix(messageType, "Message"))
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type MessageTest struct {
+	Val int
+}
+
+type recordingObserver struct {
+	NopObserver
+	events    []Event
+	requests  []string
+	responses []string
+}
+
+func (o *recordingObserver) OnEvent(e Event) {
+	o.events = append(o.events, e)
+}
+
+func (o *recordingObserver) OnRequest(id PlayerID, messageType string) {
+	o.requests = append(o.requests, messageType)
+}
+
+func (o *recordingObserver) OnResponse(id PlayerID, messageType string, elapsed time.Duration, err error) {
+	o.responses = append(o.responses, messageType)
+}
+
+func TestObserverEvents(t *testing.T) {
+	g := getSeatingGame()
+	o := &recordingObserver{}
+	g.AddObserver(o)
+
+	g.AddAll(EventTest1{Val: 1})
+	g.Add(EventTest2{Val: 2}, []PlayerID{2})
+	g.EventLog.Clear()
+	g.AddNone(EventTest3{Val: 3})
+
+	if len(o.events) != 3 {
+		t.Fatalf("Expected 3 observed events, got: %+v", o.events)
+	}
+	if !o.events[0].Show[ShowAll] {
+		t.Errorf("Expected first event shown to all, got: %+v", o.events[0].Show)
+	}
+	if !o.events[1].Show[2] || o.events[1].Show[ShowAll] {
+		t.Errorf("Expected second event shown to player 2 only, got: %+v", o.events[1].Show)
+	}
+	if len(o.events[2].Show) != 0 {
+		t.Errorf("Expected third event shown to no one, got: %+v", o.events[2].Show)
+	}
+}
+
+func TestObserverMessages(t *testing.T) {
+	g := getSeatingGame()
+	c := g.BaseComms()
+
+	// Observers added after the comms are made are told too
+	o := &recordingObserver{}
+	g.AddObserver(o)
+
+	p := &Player{Runnable: &RunnablePlayerMock{}, ID: 1}
+	c.SendMessage(context.Background(), p, MessageTest{Val: 1})
+	if len(o.requests) != 1 || o.requests[0] != "test" {
+		t.Errorf("Expected one test request, got: %+v", o.requests)
+	}
+	if len(o.responses) != 1 || o.responses[0] != "test" {
+		t.Errorf("Expected one test response, got: %+v", o.responses)
+	}
+}
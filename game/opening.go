@@ -0,0 +1,155 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boardgamesai/games/util"
+)
+
+// Opening is a named starting position, in the format the game's SetPosition takes.
+type Opening struct {
+	Name     string
+	Position string
+}
+
+// OpeningSuiter is implemented by games that can make up openings from random legal moves,
+// and that come with a bundled suite of them.
+type OpeningSuiter interface {
+	Openings() []Opening
+	RandomOpening(r *util.Rand, plies int) (string, error)
+}
+
+// RandomOpenings makes n different openings of the given number of plies using g's RandomOpening.
+func RandomOpenings(g OpeningSuiter, r *util.Rand, n, plies int) ([]Opening, error) {
+	openings := []Opening{}
+	seen := map[string]bool{}
+
+	// Short openings can run out of different positions, so don't try forever
+	for tries := 0; len(openings) < n && tries < n*100; tries++ {
+		position, err := g.RandomOpening(r, plies)
+		if err != nil {
+			return nil, err
+		}
+		if seen[position] {
+			continue
+		}
+		seen[position] = true
+
+		openings = append(openings, Opening{
+			Name:     fmt.Sprintf("%d", len(openings)+1),
+			Position: position,
+		})
+	}
+
+	if len(openings) < n {
+		return nil, fmt.Errorf("could only find %d different openings of %d plies", len(openings), plies)
+	}
+
+	return openings, nil
+}
+
+// ReadOpenings reads a suite of openings, one per line as "name: position" or just the position,
+// in which case it's named by its number in the suite. Blank lines and lines starting with # are skipped.
+func ReadOpenings(r io.Reader) ([]Opening, error) {
+	openings := []Opening{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		opening := Opening{
+			Name:     fmt.Sprintf("%d", len(openings)+1),
+			Position: line,
+		}
+		if name, position, found := strings.Cut(line, ":"); found {
+			opening.Name = strings.TrimSpace(name)
+			opening.Position = strings.TrimSpace(position)
+		}
+
+		openings = append(openings, opening)
+	}
+
+	return openings, scanner.Err()
+}
+
+// WriteOpenings writes a suite of openings in the format ReadOpenings reads.
+func WriteOpenings(w io.Writer, openings []Opening) error {
+	for _, opening := range openings {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", opening.Name, opening.Position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpeningResult is how the games played from an opening went, one for each seat order.
+type OpeningResult struct {
+	Opening
+	Games []SeatedGame
+}
+
+// Score is how many points the player got from the opening: one for each win and half for each tie.
+func (r OpeningResult) Score(id PlayerID) float64 {
+	score := 0.0
+	for _, g := range r.Games {
+		for _, place := range g.Places {
+			if place.Player.ID != id || place.Rank != 1 {
+				continue
+			}
+
+			if place.Tie {
+				score += 0.5
+			} else {
+				score += 1
+			}
+		}
+	}
+
+	return score
+}
+
+// PlayOpenings plays a two-player game from each opening twice, with the players swapping seats in between,
+// so that both get the same positions and any edge from moving first evens out. Playing stops early if ctx
+// is done, returning the results so far along with the error. The game is left at the usual start, with
+// players seated as the Seating option says.
+func PlayOpenings(ctx context.Context, g Playable, openings []Opening) ([]OpeningResult, error) {
+	players := g.GetPlayers()
+	if len(players) != 2 {
+		return nil, fmt.Errorf("openings need a two-player game, this one has %d", len(players))
+	}
+
+	defer g.SetSeats(nil)
+	defer g.SetPosition("")
+
+	seatOrders := [][]PlayerID{
+		{players[0].ID, players[1].ID},
+		{players[1].ID, players[0].ID},
+	}
+
+	results := []OpeningResult{}
+	for _, opening := range openings {
+		if err := g.SetPosition(opening.Position); err != nil {
+			return results, fmt.Errorf("opening %s: %w", opening.Name, err)
+		}
+
+		result := OpeningResult{Opening: opening}
+		for _, seats := range seatOrders {
+			played, err := playSeated(ctx, g, seats)
+			if err != nil {
+				return results, err
+			}
+			result.Games = append(result.Games, played)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadOpenings(t *testing.T) {
+	suite := `
+# A comment
+first: X.O/.X./... O
+..X/.../... O
+`
+	openings, err := ReadOpenings(strings.NewReader(suite))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []Opening{
+		{Name: "first", Position: "X.O/.X./... O"},
+		{Name: "2", Position: "..X/.../... O"},
+	}
+	if len(openings) != len(expected) {
+		t.Fatalf("Expected %d openings, got: %+v", len(expected), openings)
+	}
+	for i := range expected {
+		if openings[i] != expected[i] {
+			t.Errorf("Expected %+v, got: %+v", expected[i], openings[i])
+		}
+	}
+
+	b := strings.Builder{}
+	if err := WriteOpenings(&b, openings); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	reread, err := ReadOpenings(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(reread) != len(openings) || reread[1] != openings[1] {
+		t.Errorf("Openings changed writing them out, got: %+v", reread)
+	}
+}
+
+func TestOpeningResultScore(t *testing.T) {
+	p1 := Player{ID: 1}
+	p2 := Player{ID: 2}
+	result := OpeningResult{
+		Games: []SeatedGame{
+			{Seats: []PlayerID{1, 2}, Places: []Place{{Player: p1, Rank: 1}, {Player: p2, Rank: 2}}},
+			{Seats: []PlayerID{2, 1}, Places: []Place{{Player: p2, Rank: 1, Tie: true}, {Player: p1, Rank: 1, Tie: true}}},
+		},
+	}
+
+	if score := result.Score(1); score != 1.5 {
+		t.Errorf("Expected player 1 to score 1.5, got: %.1f", score)
+	}
+	if score := result.Score(2); score != 0.5 {
+		t.Errorf("Expected player 2 to score 0.5, got: %.1f", score)
+	}
+}
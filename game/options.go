@@ -0,0 +1,32 @@
+package game
+
+import "time"
+
+// DQRanking decides how the remaining players are placed when someone is disqualified
+// from a game with more than two players. The disqualified player is always placed last.
+type DQRanking string
+
+const (
+	DQRankStandings = DQRanking("standings") // Rank remaining players by the standings at the time of the DQ
+	DQRankShared    = DQRanking("shared")    // Remaining players share first place
+)
+
+// Seating decides where the players sit, and so the turn order, at the start of each game.
+type Seating string
+
+const (
+	SeatingRandom  = Seating("random")  // Shuffle the players before each game
+	SeatingFixed   = Seating("fixed")   // Seat the players in the order they're given
+	SeatingRotated = Seating("rotated") // Start in the order given, then move everyone one seat along each game
+)
+
+type Options struct {
+	DQRanking  DQRanking     // Defaults to DQRankStandings
+	Substitute bool          // Hand a DQ'd player's seat to a fallback AI and finish the game, where supported
+	Seating    Seating       // Defaults to SeatingRandom, and is overridden by SetSeats
+	Reveal     Reveal        // Who sees hidden events once the game is over, defaults to RevealPlayers
+	MaxPlies   int           // Stop after this many moves and decide the game by its tie-break rule, 0 for no limit
+	Adjudicate bool          // Decide the game as soon as the outcome is settled, in games that can tell
+	Ponder     bool          // Tell players about each move as it happens, so they can think on others' time
+	WarmUp     time.Duration // Time each player gets to warm up before the game starts, 0 for no warm-up
+}
@@ -1,12 +1,27 @@
 package game
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type Playable interface {
 	Play() error
+	PlayContext(ctx context.Context) error
+	Resume(ctx context.Context, s *Snapshot) error
+	LastCheckpoint() *Snapshot
+	Termination() Termination
 	GetPlayers() []*Player
 	Events() []fmt.Stringer
+	EventsFor(v View) []fmt.Stringer
 	RawEvents() EventLog
+	RawEventsFor(v View) []Event
 	Places() []Place
 	LoggedOutput(id PlayerID) string
+	Log(id PlayerID) PlayerLog
+	SetOptions(options Options)
+	SetPosition(position string) error
+	SetSeats(ids []PlayerID) error
+	SetSeed(seed uint64)
+	AddObserver(o Observer)
 }
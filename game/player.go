@@ -5,9 +5,11 @@ import "fmt"
 type PlayerID uint64
 
 type Player struct {
-	Runnable `json:"-"`
-	ID       PlayerID
-	Name     string `json:",omitempty"`
+	Runnable   `json:"-"`
+	ID         PlayerID
+	Name       string `json:",omitempty"`
+	LegalMoves bool   `json:"-"` // Opted in to getting the legal moves with each move request
+	FullState  bool   `json:"-"` // Opted in to getting the whole state of the game with each move request
 }
 
 func (p *Player) String() string {
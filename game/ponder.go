@@ -0,0 +1,83 @@
+package game
+
+import (
+	"context"
+	"fmt"
+)
+
+// MessagePonder is sent with the Ponder option on, after each move, to players who aren't about to be
+// asked for one, with whatever's happened since they last heard from us. Their driver acknowledges it
+// right away, then lets the AI think in the background until the next message comes.
+type MessagePonder struct {
+	NewEvents []Event
+}
+
+// Ponder sends the players a MessagePonder, if the Ponder option is on. It stops at the first one who
+// doesn't acknowledge it, returning them along with the error, which is a DQError if it's their fault.
+func (g *Game[P, B, C]) Ponder(ctx context.Context, players []P) (P, error) {
+	var none P
+	if !g.options.Ponder {
+		return none, nil
+	}
+
+	c := g.BaseComms()
+	for _, p := range players {
+		player := p.BasePlayer()
+		if g.IsSubstitute(player.ID) {
+			continue // No one to tell
+		}
+
+		message := MessagePonder{
+			NewEvents: c.NewEvents(player.ID),
+		}
+		err := c.SendMessageNoResponse(ctx, player, message)
+		switch e := err.(type) {
+		case DQError:
+			return p, g.AddDQErrorID(&e, player.ID)
+		case *DQError:
+			return p, g.AddDQErrorID(e, player.ID)
+		case nil:
+			continue
+		}
+		return p, err
+	}
+
+	return none, nil
+}
+
+// StartPondering runs ponder in the background until the next message arrives, when its ctx is cancelled
+// and it's waited for before the message is handled, so the AI can't be thinking while its state changes.
+// Any time it takes to stop counts against the AI's response to that message.
+// Each game's driver calls it with the AI's Ponder(ctx, state), if it has one, after each move it's told
+// about, so the AI can leave whatever it works out for its next move.
+func (d *AIDriver) StartPondering(ponder func(ctx context.Context)) {
+	d.StopPondering()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan interface{}, 1)
+	d.stopPondering = func() interface{} {
+		cancel()
+		return <-done
+	}
+
+	go func() {
+		// A panic while pondering is raised again once it's stopped, so it's reported like any other.
+		defer func() {
+			done <- recover()
+		}()
+		ponder(ctx)
+	}()
+}
+
+// StopPondering stops the AI pondering, if it is, and waits for it to finish.
+func (d *AIDriver) StopPondering() {
+	if d.stopPondering == nil {
+		return
+	}
+
+	r := d.stopPondering()
+	d.stopPondering = nil
+	if r != nil {
+		panic(fmt.Sprintf("while pondering: %s", r))
+	}
+}
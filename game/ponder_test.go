@@ -0,0 +1,99 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type failingRunnable struct {
+	RunnablePlayerMock
+}
+
+func (r *failingRunnable) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	return DQError{Type: DQTypeTimeout, Msg: "too slow"}
+}
+
+func TestPonder(t *testing.T) {
+	g := getSeatingGame()
+	recorders := map[PlayerID]*RecordingRunnable{}
+	for _, p := range g.Players {
+		recorders[p.ID] = NewRecordingRunnable(&RunnablePlayerMock{})
+		p.Runnable = recorders[p.ID]
+	}
+	g.AddAll(EventResign{ID: 4})
+
+	// Nothing's sent with the option off
+	if _, err := g.Ponder(context.Background(), g.Players[:3]); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if messages := recorders[1].Messages(); len(messages) != 0 {
+		t.Fatalf("Expected no messages with pondering off, got: %+v", messages)
+	}
+
+	g.SetOptions(Options{Ponder: true, Substitute: true})
+	g.Substitute(g.Players[2], &DQError{ID: 3, Type: DQTypeRuntime})
+	for i := 0; i < 2; i++ {
+		if _, err := g.Ponder(context.Background(), g.Players[:3]); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	tests := []struct {
+		id        PlayerID
+		newEvents []int
+	}{
+		{1, []int{2, 0}}, // The resign and substitute events, then nothing new
+		{2, []int{2, 0}},
+		{3, []int{}}, // Substituted
+		{4, []int{}}, // Not asked
+	}
+
+	for _, test := range tests {
+		messages := recorders[test.id].Messages()
+		if len(messages) != len(test.newEvents) {
+			t.Errorf("ID %d: expected %d messages, got: %+v", test.id, len(test.newEvents), messages)
+			continue
+		}
+		for i, m := range messages {
+			ponder := MessagePonder{}
+			json.Unmarshal(m.Data, &ponder)
+			if m.Type != "ponder" || len(ponder.NewEvents) != test.newEvents[i] {
+				t.Errorf("ID %d message %d: expected ponder with %d events, got: %s %s", test.id, i, test.newEvents[i], m.Type, m.Data)
+			}
+		}
+	}
+
+	g.Players[1].Runnable = &failingRunnable{}
+	p, err := g.Ponder(context.Background(), g.Players[:3])
+	dqErr, ok := err.(*DQError)
+	if p != g.Players[1] || !ok || dqErr.ID != 2 || dqErr.Type != DQTypeTimeout {
+		t.Errorf("Expected ID 2 to time out, got: %+v %v", p, err)
+	}
+}
+
+func TestStartPondering(t *testing.T) {
+	d := AIDriver{}
+	stopped := false
+	d.StartPondering(func(ctx context.Context) {
+		<-ctx.Done()
+		stopped = true
+	})
+	d.StopPondering()
+	if !stopped {
+		t.Errorf("Expected pondering to have stopped")
+	}
+
+	// Stopping again is a no-op
+	d.StopPondering()
+
+	d.StartPondering(func(ctx context.Context) {
+		panic("oops")
+	})
+	defer func() {
+		if r := recover(); r != "while pondering: oops" {
+			t.Errorf("Expected the panic to be raised again, got: %v", r)
+		}
+	}()
+	d.StopPondering()
+}
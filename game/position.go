@@ -0,0 +1,45 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Custom starting positions are written FEN-style: the board's rows from top to bottom separated
+// by "/", one character per space, followed by space-separated fields such as whose turn it is.
+// Games without a board, like Hearts and Liar's Dice, use "/" to separate what each seat holds.
+
+// StartPosition is the custom position the game starts from, or "" for the usual start.
+func (g *Game[P, B, C]) StartPosition() string {
+	return g.position
+}
+
+// SetStartPosition stores a position that the specific game has already validated.
+func (g *Game[P, B, C]) SetStartPosition(position string) {
+	g.position = position
+}
+
+// SplitPosition breaks a position into its board rows and the fields after them, checking the board is
+// cols x rows in size.
+func SplitPosition(position string, cols, rows int) ([]string, []string, error) {
+	fields := strings.Fields(position)
+	if len(fields) == 0 {
+		return nil, nil, PositionError(position, "it's empty")
+	}
+
+	boardRows := strings.Split(fields[0], "/")
+	if len(boardRows) != rows {
+		return nil, nil, PositionError(position, fmt.Sprintf("expected %d rows, got %d", rows, len(boardRows)))
+	}
+	for i, row := range boardRows {
+		if len(row) != cols {
+			return nil, nil, PositionError(position, fmt.Sprintf("expected %d spaces in row %d, got %d", cols, i+1, len(row)))
+		}
+	}
+
+	return boardRows, fields[1:], nil
+}
+
+func PositionError(position, msg string) error {
+	return fmt.Errorf("invalid position %q: %s", position, msg)
+}
@@ -0,0 +1,536 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	RemoteListen = "listen" // Wait for the other side to connect
+	RemoteDial   = "dial"   // Connect to the other side
+
+	// RemoteReconnectTimeout is how long a remote player keeps trying to get back in touch with the
+	// engine after its connection drops. The engine gives up sooner, once the response is overdue.
+	RemoteReconnectTimeout = 30 * time.Second
+
+	// RemoteHelloTimeout is how long the engine waits for whoever connects to a listening socket to say
+	// hello, so a connection that stays silent doesn't keep the player from getting through.
+	RemoteHelloTimeout = 2 * time.Second
+
+	// Environment variables that have a Go driver talk to the engine over a connection
+	// instead of stdin and stdout, e.g. to run it on another machine or in a debugger.
+	RemoteEnv      = "BOARDGAMESAI_REMOTE" // Where to connect, as ParseRemote takes it
+	RemoteTokenEnv = "BOARDGAMESAI_TOKEN"  // The token the engine was given
+)
+
+// RemoteHello is the first line a remote player sends each time it connects, with the token the engine
+// was given and how many messages it's received so far, so the engine knows what to send again.
+type RemoteHello struct {
+	Token    string
+	Received int
+}
+
+// RemoteWelcome is the engine's answer to RemoteHello: how many responses it's received so far, counting
+// the "OK" sent on starting up, so the player knows whether to send its last one again. Err is set instead
+// if the player wasn't let in, and the connection is closed.
+type RemoteWelcome struct {
+	Received int
+	Err      string `json:",omitempty"`
+}
+
+// RemoteAddr is where one side of a remote connection is, as in "listen:tcp::9000" or
+// "dial:unix:/tmp/bot.sock". Mode is for the side that has it.
+type RemoteAddr struct {
+	Mode    string // RemoteListen or RemoteDial
+	Network string // tcp or unix
+	Address string
+}
+
+func (a RemoteAddr) String() string {
+	return fmt.Sprintf("%s:%s:%s", a.Mode, a.Network, a.Address)
+}
+
+// IsRemote says whether s looks like a RemoteAddr rather than, say, the path of an AI file.
+func IsRemote(s string) bool {
+	return strings.HasPrefix(s, RemoteListen+":") || strings.HasPrefix(s, RemoteDial+":")
+}
+
+func ParseRemote(s string) (RemoteAddr, error) {
+	fields := strings.SplitN(s, ":", 3)
+	if len(fields) != 3 || fields[2] == "" {
+		return RemoteAddr{}, fmt.Errorf("invalid remote address %q, want e.g. listen:tcp::9000 or dial:unix:/tmp/bot.sock", s)
+	}
+
+	a := RemoteAddr{Mode: fields[0], Network: fields[1], Address: fields[2]}
+	if a.Mode != RemoteListen && a.Mode != RemoteDial {
+		return RemoteAddr{}, fmt.Errorf("invalid remote mode %q, want listen or dial", a.Mode)
+	}
+	if a.Network != "tcp" && a.Network != "unix" {
+		return RemoteAddr{}, fmt.Errorf("invalid remote network %q, want tcp or unix", a.Network)
+	}
+	return a, nil
+}
+
+// remoteConn is what both ends of a remote connection share: getting connected, by listening or
+// dialing, and reading and writing lines with deadlines.
+type remoteConn struct {
+	addr     RemoteAddr
+	listener net.Listener
+	conn     net.Conn
+	reader   *bufio.Reader
+}
+
+// connect gets a new connection, waiting for one or dialing until it works, up to deadline.
+func (c *remoteConn) connect(ctx context.Context, deadline time.Time) error {
+	c.disconnect()
+
+	if c.addr.Mode == RemoteListen {
+		if c.listener == nil {
+			listener, err := net.Listen(c.addr.Network, c.addr.Address)
+			if err != nil {
+				return err
+			}
+			c.listener = listener
+		}
+
+		if dl, ok := c.listener.(interface{ SetDeadline(time.Time) error }); ok {
+			dl.SetDeadline(deadline)
+		}
+		stop := context.AfterFunc(ctx, func() {
+			c.listener.Close()
+		})
+		defer stop()
+
+		conn, err := c.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				c.listener = nil // It's closed
+				return ctx.Err()
+			}
+			return err
+		}
+		c.use(conn)
+		return nil
+	}
+
+	// The other side might not be up yet, so keep trying
+	for {
+		dialer := net.Dialer{Deadline: deadline}
+		conn, err := dialer.DialContext(ctx, c.addr.Network, c.addr.Address)
+		if err == nil {
+			c.use(conn)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *remoteConn) use(conn net.Conn) {
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+}
+
+func (c *remoteConn) disconnect() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+func (c *remoteConn) close() {
+	c.disconnect()
+	if c.listener != nil {
+		c.listener.Close()
+		c.listener = nil
+	}
+}
+
+// readLine reads a whole line, without the newline, giving up at deadline or once ctx is done.
+func (c *remoteConn) readLine(ctx context.Context, deadline time.Time) ([]byte, error) {
+	if c.conn == nil {
+		return nil, net.ErrClosed
+	}
+
+	c.conn.SetReadDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		c.conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return line[:len(line)-1], nil
+}
+
+func (c *remoteConn) writeLine(line []byte, deadline time.Time) error {
+	if c.conn == nil {
+		return net.ErrClosed
+	}
+
+	c.conn.SetWriteDeadline(deadline)
+	_, err := c.conn.Write(append(append([]byte{}, line...), '\n'))
+	return err
+}
+
+func (c *remoteConn) writeJSON(v interface{}, deadline time.Time) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeLine(line, deadline)
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RemotePlayer is a Runnable for a player that's running somewhere else, like another machine or a
+// debugger, and talks to the engine over a TCP or Unix socket. It's the same protocol as a local player
+// uses over stdin and stdout, except that the player starts each connection with a RemoteHello carrying
+// the shared token, and the engine answers with a RemoteWelcome. If the connection drops, the player can
+// reconnect, and whatever got lost is sent again, as long as the response it owes comes in on time.
+type RemotePlayer struct {
+	remoteConn
+	token      string
+	sent       int         // Messages sent
+	received   int         // Responses received, counting the "OK" sent on starting up
+	pending    []byte      // The last message sent, in case the player has to be sent it again
+	annotation *Annotation // Whatever came with the last response
+}
+
+func NewRemotePlayer(addr RemoteAddr, token string) *RemotePlayer {
+	return &RemotePlayer{
+		remoteConn: remoteConn{addr: addr},
+		token:      token,
+	}
+}
+
+// Run waits for the player to connect, or connects to them, and for them to say they're ready, which
+// both have to happen within PlayerLaunchTimeout.
+func (p *RemotePlayer) Run(ctx context.Context) error {
+	p.sent, p.received, p.pending = 0, 0, nil
+
+	deadline := time.Now().Add(time.Second * PlayerLaunchTimeout)
+	if err := p.reconnect(ctx, deadline); err != nil {
+		return err
+	}
+
+	response, err := p.readResponse(ctx, deadline)
+	if err != nil {
+		return err
+	}
+	if string(response) != "OK" {
+		return fmt.Errorf("got non-OK response when launching player: %s", response)
+	}
+	return nil
+}
+
+func (p *RemotePlayer) CleanUp() error {
+	p.close()
+	return nil
+}
+
+func (p *RemotePlayer) SendMessage(ctx context.Context, message interface{}) ([]byte, error) {
+	p.annotation = nil
+
+	messageJSON, err := json.Marshal(&message)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	mJSON, err := json.Marshal(&Message{
+		Type: MessageType(message),
+		Data: messageJSON,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	deadline := time.Now().Add(ResponseTimeout(ctx))
+	p.pending = mJSON
+	p.sent++
+
+	// If this doesn't get through, it's sent again once they reconnect
+	p.writeLine(mJSON, deadline)
+
+	response, err := p.readResponse(ctx, deadline)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	mr := MessageResponse{}
+	if err := json.Unmarshal(response, &mr); err != nil {
+		return response, err
+	}
+	if mr.Err != nil {
+		return []byte{}, mr.Err
+	}
+
+	p.annotation = mr.Annotation
+	return mr.Data, nil
+}
+
+func (p *RemotePlayer) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	response, err := p.SendMessage(ctx, message)
+	if err != nil {
+		return err
+	}
+	if string(response) != "\"OK\"" { // Hack - this is JSON-encoded
+		return fmt.Errorf("got non-OK response: %s", response)
+	}
+
+	return nil
+}
+
+func (p *RemotePlayer) LastAnnotation() *Annotation {
+	return p.annotation
+}
+
+// Stderr is empty, since whatever the player logs stays where it's running.
+func (p *RemotePlayer) Stderr() string {
+	return ""
+}
+
+func (p *RemotePlayer) String() string {
+	return p.addr.String()
+}
+
+// readResponse reads the player's next response, waiting for them to reconnect if the connection
+// drops, until deadline.
+func (p *RemotePlayer) readResponse(ctx context.Context, deadline time.Time) ([]byte, error) {
+	for {
+		response, err := p.readLine(ctx, deadline)
+		if err == nil {
+			p.received++
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if isTimeout(err) {
+			return nil, DQError{
+				Type: DQTypeTimeout,
+				Msg:  fmt.Sprintf("Timeout reading player response after %s", ResponseTimeout(ctx)),
+			}
+		}
+
+		if err := p.reconnect(ctx, deadline); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// reconnect gets the player connected again, and sends them the last message if they missed it.
+func (p *RemotePlayer) reconnect(ctx context.Context, deadline time.Time) error {
+	for {
+		if err := p.connect(ctx, deadline); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return DQError{
+				Type: DQTypeDisconnect,
+				Msg:  fmt.Sprintf("couldn't connect to %s: %s", p.addr, err),
+			}
+		}
+
+		helloDeadline := deadline
+		if p.addr.Mode == RemoteListen && time.Now().Add(RemoteHelloTimeout).Before(deadline) {
+			helloDeadline = time.Now().Add(RemoteHelloTimeout)
+		}
+
+		hello := RemoteHello{}
+		line, err := p.readLine(ctx, helloDeadline)
+		if err == nil {
+			err = json.Unmarshal(line, &hello)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue // Try again with someone else
+		}
+
+		if subtle.ConstantTimeCompare([]byte(hello.Token), []byte(p.token)) != 1 {
+			p.writeJSON(RemoteWelcome{Err: "bad token"}, deadline)
+			p.disconnect()
+
+			// Whoever's at the address we dialed isn't our player, but anyone can try a listening socket
+			if p.addr.Mode == RemoteDial {
+				return DQError{
+					Type: DQTypeRuntime,
+					Msg:  fmt.Sprintf("player at %s sent the wrong token", p.addr),
+				}
+			}
+			continue
+		}
+
+		if err := p.writeJSON(RemoteWelcome{Received: p.received}, deadline); err != nil {
+			continue
+		}
+		if hello.Received < p.sent {
+			if err := p.writeLine(p.pending, deadline); err != nil {
+				continue
+			}
+		}
+		return nil
+	}
+}
+
+// RemoteLink is the player's end of a RemotePlayer: an io.Reader of the engine's messages and an
+// io.Writer for responses, which gets back in touch with the engine by itself if the connection drops,
+// for up to RemoteReconnectTimeout. Only whole lines are read, so nothing is half-received.
+type RemoteLink struct {
+	remoteConn
+	token    string
+	received int    // Lines read from the engine
+	written  int    // Lines written to the engine
+	last     []byte // The last line written, in case the engine has to be sent it again
+	partial  []byte // What's been written of the next line so far
+	unread   []byte // What's left of the last line read
+}
+
+// ConnectRemote connects to the engine, or waits for it to connect, and says hello.
+func ConnectRemote(addr RemoteAddr, token string) (*RemoteLink, error) {
+	l := &RemoteLink{
+		remoteConn: remoteConn{addr: addr},
+		token:      token,
+	}
+	if err := l.reconnect(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ConnectRemoteFromEnv connects the way the environment says to, returning nil if it doesn't.
+func ConnectRemoteFromEnv() (*RemoteLink, error) {
+	remote := os.Getenv(RemoteEnv)
+	if remote == "" {
+		return nil, nil
+	}
+
+	addr, err := ParseRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+	return ConnectRemote(addr, os.Getenv(RemoteTokenEnv))
+}
+
+func (l *RemoteLink) Read(b []byte) (int, error) {
+	for len(l.unread) == 0 {
+		line, err := l.readLine(context.Background(), time.Time{})
+		if err != nil {
+			if err := l.reconnect(); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		l.received++
+		l.unread = append(line, '\n')
+	}
+
+	n := copy(b, l.unread)
+	l.unread = l.unread[n:]
+	return n, nil
+}
+
+func (l *RemoteLink) Write(b []byte) (int, error) {
+	l.partial = append(l.partial, b...)
+	for {
+		i := strings.IndexByte(string(l.partial), '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+
+		l.last = append([]byte{}, l.partial[:i]...)
+		l.partial = l.partial[i+1:]
+		l.written++
+
+		// If this doesn't get through, it's sent again once we reconnect
+		if err := l.writeLine(l.last, time.Time{}); err != nil {
+			if err := l.reconnect(); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+func (l *RemoteLink) Close() error {
+	l.close()
+	return nil
+}
+
+// reconnect gets back in touch with the engine, and sends it our last line if it missed it.
+func (l *RemoteLink) reconnect() error {
+	deadline := time.Now().Add(RemoteReconnectTimeout)
+	for {
+		if err := l.connect(context.Background(), deadline); err != nil {
+			return fmt.Errorf("couldn't connect to the engine at %s: %w", l.addr, err)
+		}
+
+		welcome := RemoteWelcome{}
+		err := l.writeJSON(RemoteHello{Token: l.token, Received: l.received}, deadline)
+		if err == nil {
+			var line []byte
+			if line, err = l.readLine(context.Background(), deadline); err == nil {
+				err = json.Unmarshal(line, &welcome)
+			}
+		}
+		if err != nil {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("couldn't say hello to the engine at %s: %w", l.addr, err)
+			}
+			continue
+		}
+		if welcome.Err != "" {
+			l.close()
+			return fmt.Errorf("engine at %s turned us away: %s", l.addr, welcome.Err)
+		}
+
+		if welcome.Received < l.written {
+			if err := l.writeLine(l.last, deadline); err != nil {
+				continue
+			}
+		}
+		return nil
+	}
+}
+
+// markerStripper passes along what a remote player's driver logs, minus the markers the engine uses to
+// split up a local player's output, since the engine isn't reading it.
+type markerStripper struct {
+	w io.Writer
+}
+
+func (m markerStripper) Write(b []byte) (int, error) {
+	if string(b) == LogMarker+"\n" {
+		return len(b), nil
+	}
+	return m.w.Write(b)
+}
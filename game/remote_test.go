@@ -0,0 +1,230 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected RemoteAddr
+		valid    bool
+	}{
+		{"listen:tcp::9000", RemoteAddr{Mode: RemoteListen, Network: "tcp", Address: ":9000"}, true},
+		{"dial:tcp:host:9000", RemoteAddr{Mode: RemoteDial, Network: "tcp", Address: "host:9000"}, true},
+		{"dial:unix:/tmp/bot.sock", RemoteAddr{Mode: RemoteDial, Network: "unix", Address: "/tmp/bot.sock"}, true},
+		{"listen:tcp:", RemoteAddr{}, false},
+		{"listen:udp::9000", RemoteAddr{}, false},
+		{"call:tcp::9000", RemoteAddr{}, false},
+		{"bot.go", RemoteAddr{}, false},
+	}
+
+	for _, test := range tests {
+		addr, err := ParseRemote(test.s)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid %t, got error: %v", test.s, test.valid, err)
+		}
+		if addr != test.expected {
+			t.Errorf("%q: expected %+v, got %+v", test.s, test.expected, addr)
+		}
+	}
+}
+
+// runStubClient connects to the engine and answers every message OK until the game's over, dropping
+// the connection after reading the first message, before it responds.
+func runStubClient(t *testing.T, addr RemoteAddr, token string) {
+	link, err := ConnectRemote(addr, token)
+	if err != nil {
+		t.Errorf("Unexpected error connecting: %s", err)
+		return
+	}
+	defer link.Close()
+
+	d := AIDriver{}
+	d.SetIO(link, link, io.Discard)
+	d.Setup()
+
+	for i := 0; ; i++ {
+		message, err := d.GetNextMessage()
+		if err != nil {
+			t.Errorf("Unexpected error reading message: %s", err)
+			return
+		}
+		if i == 0 {
+			link.disconnect()
+		}
+
+		d.PrintResponse(d.OkJSON())
+		if message.Type == "gameover" {
+			return
+		}
+	}
+}
+
+func TestRemotePlayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	r := NewRemotePlayer(RemoteAddr{Mode: RemoteListen, Network: "unix", Address: path}, "secret")
+	defer r.CleanUp()
+
+	clientAddr := RemoteAddr{Mode: RemoteDial, Network: "unix", Address: path}
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+
+		// Someone without the token is turned away, and the engine waits for someone else
+		if _, err := ConnectRemote(clientAddr, "guess"); err == nil {
+			t.Errorf("Expected a bad token to be turned away")
+		}
+		runStubClient(t, clientAddr, "secret")
+	}()
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The client drops the connection before responding, and sends the response again once it's back
+	if err := r.SendMessageNoResponse(context.Background(), MessagePonder{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// This time we drop it before the client reads the message, so we send it again once it's back
+	r.disconnect()
+	if err := r.SendMessageNoResponse(context.Background(), MessagePonder{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := r.SendMessageNoResponse(context.Background(), MessageGameOver{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	<-done
+}
+
+func TestRemotePlayerAnnotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	r := NewRemotePlayer(RemoteAddr{Mode: RemoteListen, Network: "unix", Address: path}, "secret")
+	defer r.CleanUp()
+
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+
+		link, err := ConnectRemote(RemoteAddr{Mode: RemoteDial, Network: "unix", Address: path}, "secret")
+		if err != nil {
+			t.Errorf("Unexpected error connecting: %s", err)
+			return
+		}
+		defer link.Close()
+
+		d := AIDriver{}
+		d.SetIO(link, link, io.Discard)
+		d.Setup()
+
+		// Annotate the move, but not the game over
+		d.GetNextMessage()
+		d.Annotate(&Annotation{Depth: 4, Comment: "forced"})
+		d.PrintResponse(d.OkJSON())
+
+		d.GetNextMessage()
+		d.PrintResponse(d.OkJSON())
+	}()
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	l := &EventLog{}
+	c := Comms{EventLog: l}
+	p := &Player{ID: 1, Runnable: r}
+	if _, err := c.SendMessage(context.Background(), p, MessagePonder{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(*l) != 1 || (*l)[0].Type != EventTypeAnnotation {
+		t.Fatalf("Expected the annotation to be logged, got: %+v", *l)
+	}
+	e := EventAnnotation{}
+	json.Unmarshal((*l)[0].Data, &e)
+	if e.ID != 1 || e.Depth != 4 || e.Comment != "forced" {
+		t.Errorf("Expected the client's annotation, got: %+v", e)
+	}
+
+	if err := c.SendMessageNoResponse(context.Background(), p, MessageGameOver{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if len(*l) != 1 {
+		t.Errorf("Expected nothing more to be logged, got: %+v", *l)
+	}
+	<-done
+}
+
+func TestRemotePlayerSilentConnection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	r := NewRemotePlayer(RemoteAddr{Mode: RemoteListen, Network: "unix", Address: path}, "secret")
+	defer r.CleanUp()
+
+	clientAddr := RemoteAddr{Mode: RemoteDial, Network: "unix", Address: path}
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+
+		// Someone connects and never says hello, which shouldn't keep the player out for long
+		silent, err := net.Dial("unix", path)
+		for err != nil {
+			time.Sleep(10 * time.Millisecond)
+			silent, err = net.Dial("unix", path)
+		}
+		defer silent.Close()
+
+		runStubClient(t, clientAddr, "secret")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*RemoteHelloTimeout)
+	defer cancel()
+	if err := r.Run(ctx); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := r.SendMessageNoResponse(ctx, MessageGameOver{}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	<-done
+}
+
+func TestRemotePlayerDisconnect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	r := NewRemotePlayer(RemoteAddr{Mode: RemoteListen, Network: "unix", Address: path}, "secret")
+	defer r.CleanUp()
+
+	links := make(chan *RemoteLink)
+	go func() {
+		link, err := ConnectRemote(RemoteAddr{Mode: RemoteDial, Network: "unix", Address: path}, "secret")
+		if err != nil {
+			t.Errorf("Unexpected error connecting: %s", err)
+		}
+		link.Write([]byte("OK\n"))
+		links <- link
+	}()
+
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	link := <-links
+	ctx := WithResponseTimeout(context.Background(), 200*time.Millisecond)
+
+	// Connected but not responding
+	_, err := r.SendMessage(ctx, MessagePonder{})
+	if dqErr, ok := err.(DQError); !ok || dqErr.Type != DQTypeTimeout {
+		t.Errorf("Expected a timeout, got: %v", err)
+	}
+
+	// Gone for good
+	link.Close()
+	_, err = r.SendMessage(ctx, MessagePonder{})
+	if dqErr, ok := err.(DQError); !ok || dqErr.Type != DQTypeDisconnect {
+		t.Errorf("Expected a disconnect, got: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed replay.html
+var replayPage string
+
+// Replay is a finished game as the replay page shows it, with the events as each view saw them.
+type Replay struct {
+	Game        Name
+	Players     []*Player
+	Views       []ReplayView
+	Places      []Place
+	Termination Termination
+	Error       string `json:",omitempty"` // What the game ended with, like a DQ, if anything
+}
+
+// ReplayView is the events seen one way: "full", "public", or a player's ID.
+type ReplayView struct {
+	View   string
+	Label  string
+	Events []StreamedEvent
+}
+
+// NewReplay makes a replay of a game that's just been played, which ended with err.
+func NewReplay(gameName Name, g Playable, err error) Replay {
+	r := Replay{
+		Game:        gameName,
+		Players:     g.GetPlayers(),
+		Places:      g.Places(),
+		Termination: g.Termination(),
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	r.AddView("full", "Everything", g.RawEventsFor(ViewFull), g.EventsFor(ViewFull))
+	r.AddView("public", "Spectator", g.RawEventsFor(ViewPublic), g.EventsFor(ViewPublic))
+	for _, p := range r.Players {
+		v := ViewPlayer(p.ID)
+		r.AddView(fmt.Sprintf("%d", p.ID), fmt.Sprintf("As %s", p), g.RawEventsFor(v), g.EventsFor(v))
+	}
+
+	return r
+}
+
+// AddView adds the events seen one way, along with how each reads.
+func (r *Replay) AddView(view, label string, events []Event, texts []fmt.Stringer) {
+	rv := ReplayView{View: view, Label: label, Events: []StreamedEvent{}}
+	for i, e := range events {
+		streamed := StreamedEvent{Event: e}
+		if i < len(texts) && texts[i] != nil {
+			streamed.Text = texts[i].String()
+		}
+		rv.Events = append(rv.Events, streamed)
+	}
+	r.Views = append(r.Views, rv)
+}
+
+// WriteHTML writes the replay as a single page that works offline, drawing the board, cards or dice
+// at each step, with controls to step through it, play it and pick whose view to see it from. The page
+// holds every view, hidden events and all, so it's no place to keep secrets from anyone it's shared with.
+func (r Replay) WriteHTML(w io.Writer) error {
+	// This escapes <, > and &, so nothing in it can end the script it's in
+	replayJSON, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	page := strings.Replace(replayPage, "/*REPLAY*/null", string(replayJSON), 1)
+	_, err = io.WriteString(w, page)
+	return err
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type replayText string
+
+func (t replayText) String() string {
+	return string(t)
+}
+
+func TestReplayWriteHTML(t *testing.T) {
+	r := Replay{
+		Game:        TicTacToe,
+		Players:     []*Player{{ID: 1, Name: "a"}, {ID: 2, Name: "</script><script>alert(1)"}},
+		Places:      []Place{{Player: Player{ID: 1}, Rank: 1}, {Player: Player{ID: 2}, Rank: 2}},
+		Termination: TerminationNormal,
+	}
+	events := []Event{
+		{Type: "setup", Data: json.RawMessage(`{"Players":[]}`)},
+		{Type: "move", Data: json.RawMessage(`{"ID":1,"Col":0,"Row":0}`)},
+	}
+	r.AddView("full", "Everything", events, []fmt.Stringer{nil, replayText("ID 1 plays [0,0]")})
+	r.AddView("public", "Spectator", events[1:], nil)
+
+	b := strings.Builder{}
+	if err := r.WriteHTML(&b); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	page := b.String()
+
+	// Nothing in the replay can end the script it's embedded in
+	if strings.Count(page, "</script>") != 1 {
+		t.Errorf("Expected the replay to be escaped, got: %s", page)
+	}
+
+	_, after, ok := strings.Cut(page, "const replay = ")
+	if !ok {
+		t.Fatalf("Expected the replay to be embedded, got: %s", page)
+	}
+	replayJSON, _, _ := strings.Cut(after, ";\n")
+
+	embedded := Replay{}
+	if err := json.Unmarshal([]byte(replayJSON), &embedded); err != nil {
+		t.Fatalf("Couldn't decode %q: %s", replayJSON, err)
+	}
+	if embedded.Game != TicTacToe || embedded.Players[1].Name != r.Players[1].Name || len(embedded.Places) != 2 {
+		t.Errorf("Expected the game as it was, got: %+v", embedded)
+	}
+	if len(embedded.Views) != 2 || len(embedded.Views[0].Events) != 2 || len(embedded.Views[1].Events) != 1 {
+		t.Fatalf("Expected both views, got: %+v", embedded.Views)
+	}
+	if embedded.Views[0].Events[0].Text != "" || embedded.Views[0].Events[1].Text != "ID 1 plays [0,0]" {
+		t.Errorf("Expected how each event reads, got: %+v", embedded.Views[0].Events)
+	}
+}
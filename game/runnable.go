@@ -1,9 +1,11 @@
 package game
 
+import "context"
+
 type Runnable interface {
-	Run() error
+	Run(ctx context.Context) error
 	CleanUp() error
-	SendMessage(message interface{}) ([]byte, error)
-	SendMessageNoResponse(message interface{}) error
+	SendMessage(ctx context.Context, message interface{}) ([]byte, error)
+	SendMessageNoResponse(ctx context.Context, message interface{}) error
 	Stderr() string
 }
@@ -2,7 +2,7 @@ package game
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,24 +31,27 @@ type RunnablePlayer struct {
 	cmd          *exec.Cmd
 	cmdStdin     *io.WriteCloser
 	cmdStdout    *bufio.Reader
-	cmdStderr    *bytes.Buffer
+	cmdStderr    *LogCapture
+	logCap       int // Bytes of stderr to keep
 	responseChan chan []byte
+	annotation   *Annotation // Whatever came with the last response
 }
 
 func NewRunnablePlayer(gameName string, filePath string) *RunnablePlayer {
 	player := RunnablePlayer{
 		gameName: gameName,
 		filePath: filePath,
+		logCap:   DefaultLogCap,
 	}
 	return &player
 }
 
-func (p *RunnablePlayer) Run() error {
+func (p *RunnablePlayer) Run(ctx context.Context) error {
 	if err := p.setupFiles(); err != nil {
 		return err
 	}
 
-	if err := p.launchProcess(); err != nil {
+	if err := p.launchProcess(ctx); err != nil {
 		return err
 	}
 
@@ -66,6 +69,8 @@ func (p *RunnablePlayer) Run() error {
 			Type: DQTypeTimeout,
 			Msg:  fmt.Sprintf("Timeout launching player after %ds", PlayerLaunchTimeout),
 		}
+	case <-ctx.Done():
+		err = ctx.Err()
 	}
 
 	return err
@@ -104,7 +109,9 @@ func (p *RunnablePlayer) CleanUp() error {
 	return err2
 }
 
-func (p *RunnablePlayer) SendMessage(message interface{}) ([]byte, error) {
+func (p *RunnablePlayer) SendMessage(ctx context.Context, message interface{}) ([]byte, error) {
+	p.annotation = nil
+
 	// Let's use reflection to get the type of this message
 	messageType := reflect.TypeOf(message).Name()
 	if messageType[0:7] != "Message" {
@@ -114,6 +121,10 @@ func (p *RunnablePlayer) SendMessage(message interface{}) ([]byte, error) {
 	// Hack off the "Message" on the front and lowercase it
 	messageType = strings.ToLower(messageType[7:])
 
+	if p.cmdStderr != nil {
+		p.cmdStderr.StartExchange(messageType)
+	}
+
 	messageJSON, err := json.Marshal(&message)
 	if err != nil {
 		return []byte{}, err
@@ -142,12 +153,14 @@ func (p *RunnablePlayer) SendMessage(message interface{}) ([]byte, error) {
 	select {
 	case response = <-p.responseChan:
 		// Do nothing, the assignment above is the important thing
-	case <-time.After(time.Second * PlayerResponseTimeout):
+	case <-time.After(ResponseTimeout(ctx)):
 		err := DQError{
 			Type: DQTypeTimeout,
-			Msg:  fmt.Sprintf("Timeout reading player response after %ds", PlayerResponseTimeout),
+			Msg:  fmt.Sprintf("Timeout reading player response after %s", ResponseTimeout(ctx)),
 		}
 		return response, err
+	case <-ctx.Done():
+		return response, ctx.Err()
 	}
 
 	// Take apart our response so we can return the error if there is one
@@ -160,11 +173,12 @@ func (p *RunnablePlayer) SendMessage(message interface{}) ([]byte, error) {
 		return []byte{}, mr.Err
 	}
 
+	p.annotation = mr.Annotation
 	return mr.Data, nil
 }
 
-func (p *RunnablePlayer) SendMessageNoResponse(message interface{}) error {
-	response, err := p.SendMessage(message)
+func (p *RunnablePlayer) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	response, err := p.SendMessage(ctx, message)
 	if err != nil {
 		return err
 	}
@@ -175,13 +189,33 @@ func (p *RunnablePlayer) SendMessageNoResponse(message interface{}) error {
 	return nil
 }
 
+func (p *RunnablePlayer) LastAnnotation() *Annotation {
+	return p.annotation
+}
+
 func (p *RunnablePlayer) Stderr() string {
 	if p.cmdStderr == nil {
 		return ""
 	}
+	return p.cmdStderr.Text()
+}
 
-	buf := *p.cmdStderr
-	return buf.String()
+// Log is what the player has logged to stderr, tagged with the message it was handling at the time.
+func (p *RunnablePlayer) Log() PlayerLog {
+	if p.cmdStderr == nil {
+		return PlayerLog{}
+	}
+	return p.cmdStderr.Log()
+}
+
+// SetLogCap sets how many bytes of the player's stderr are kept, from the next time it's run.
+func (p *RunnablePlayer) SetLogCap(limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("log cap has to be at least 1 byte, got %d", limit)
+	}
+
+	p.logCap = limit
+	return nil
 }
 
 func (p *RunnablePlayer) String() string {
@@ -259,8 +293,9 @@ func (p *RunnablePlayer) driverFilePath() (string, error) {
 	return os.Getenv("GOPATH") + "/pkg/mod/" + path + "@" + version + "/" + p.gameName + "/ai/main.go", nil
 }
 
-func (p *RunnablePlayer) launchProcess() error {
-	cmd := exec.Command("go", "run", p.runDir+"/main.go", p.runDir+"/ai.go")
+func (p *RunnablePlayer) launchProcess(ctx context.Context) error {
+	// The process is killed if ctx is done before the game finishes.
+	cmd := exec.CommandContext(ctx, "go", "run", p.runDir+"/main.go", p.runDir+"/ai.go")
 	p.cmd = cmd
 
 	stdin, err := cmd.StdinPipe()
@@ -275,9 +310,8 @@ func (p *RunnablePlayer) launchProcess() error {
 	}
 	p.cmdStdout = bufio.NewReader(stdout)
 
-	var errBuf bytes.Buffer
-	cmd.Stderr = &errBuf
-	p.cmdStderr = &errBuf
+	p.cmdStderr = NewLogCapture(p.logCap)
+	cmd.Stderr = p.cmdStderr
 
 	p.responseChan = make(chan []byte, 1)
 
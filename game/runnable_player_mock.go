@@ -1,8 +1,10 @@
 package game
 
+import "context"
+
 type RunnablePlayerMock struct{}
 
-func (p *RunnablePlayerMock) Run() error {
+func (p *RunnablePlayerMock) Run(ctx context.Context) error {
 	return nil
 }
 
@@ -10,11 +12,11 @@ func (p *RunnablePlayerMock) CleanUp() error {
 	return nil
 }
 
-func (p *RunnablePlayerMock) SendMessage(message interface{}) ([]byte, error) {
+func (p *RunnablePlayerMock) SendMessage(ctx context.Context, message interface{}) ([]byte, error) {
 	return []byte{}, nil
 }
 
-func (p *RunnablePlayerMock) SendMessageNoResponse(message interface{}) error {
+func (p *RunnablePlayerMock) SendMessageNoResponse(ctx context.Context, message interface{}) error {
 	return nil
 }
 
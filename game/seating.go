@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boardgamesai/games/util"
+)
+
+// SetSeed has each game that follows use the given random seed, so they get the same deals or rolls
+// for the same seats. Passing 0 goes back to a new random seed for each game.
+func (g *Game[P, B, C]) SetSeed(seed uint64) {
+	g.seed = seed
+}
+
+// SetSeats fixes the seat order for the games that follow, first to move first, rather than
+// shuffling the players before each one. Passing nil goes back to shuffling.
+func (g *Game[P, B, C]) SetSeats(ids []PlayerID) error {
+	if ids == nil {
+		g.seats = nil
+		return nil
+	}
+
+	if len(ids) != len(g.Players) {
+		return fmt.Errorf("got %d seats for %d players", len(ids), len(g.Players))
+	}
+
+	seated := map[PlayerID]bool{}
+	for _, id := range ids {
+		found := false
+		for _, p := range g.Players {
+			if p.BasePlayer().ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no player with ID %d to seat", id)
+		}
+		if seated[id] {
+			return fmt.Errorf("player %d seated twice", id)
+		}
+		seated[id] = true
+	}
+
+	g.seats = append([]PlayerID{}, ids...)
+	return nil
+}
+
+// SeatPlayers puts the players in their seats for a new game, in the order given to SetSeats
+// if there is one, or else as the Seating option says.
+func (g *Game[P, B, C]) SeatPlayers() {
+	if g.seats == nil {
+		switch g.options.Seating {
+		case SeatingFixed:
+			// Players keep the order they're in
+		case SeatingRotated:
+			if g.seated {
+				first := g.Players[0]
+				copy(g.Players, g.Players[1:])
+				g.Players[len(g.Players)-1] = first
+			}
+		default:
+			util.ShuffleWith(g.Rand(), g.Players)
+		}
+
+		g.seated = true
+		return
+	}
+
+	for i, id := range g.seats {
+		for j := i; j < len(g.Players); j++ {
+			if g.Players[j].BasePlayer().ID == id {
+				g.Players[i], g.Players[j] = g.Players[j], g.Players[i]
+				break
+			}
+		}
+	}
+}
+
+// SeatedGame is how a game played with the players in given seats went.
+type SeatedGame struct {
+	Seats       []PlayerID // First to move first
+	Places      []Place
+	Termination Termination
+	Err         error
+}
+
+// playSeated plays a game with the players in the given seats. A game that ends badly is still recorded,
+// and it only returns an error if the game couldn't be played or was aborted.
+func playSeated(ctx context.Context, g Playable, seats []PlayerID) (SeatedGame, error) {
+	if err := g.SetSeats(seats); err != nil {
+		return SeatedGame{}, err
+	}
+
+	err := g.PlayContext(ctx)
+	if g.Termination() == TerminationAborted {
+		return SeatedGame{}, err
+	}
+
+	return SeatedGame{
+		Seats:       seats,
+		Places:      append([]Place{}, g.Places()...),
+		Termination: g.Termination(),
+		Err:         err,
+	}, nil
+}
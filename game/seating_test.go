@@ -0,0 +1,76 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+type seatingTestPlayer struct {
+	Player
+}
+
+func (p *seatingTestPlayer) BasePlayer() *Player {
+	return &p.Player
+}
+
+func getSeatingGame() *Game[*seatingTestPlayer, struct{}, struct{}] {
+	g := &Game[*seatingTestPlayer, struct{}, struct{}]{}
+	for i := 1; i <= 4; i++ {
+		g.Players = append(g.Players, &seatingTestPlayer{Player{ID: PlayerID(i)}})
+	}
+	return g
+}
+
+func seatedIDs(g *Game[*seatingTestPlayer, struct{}, struct{}]) []PlayerID {
+	ids := []PlayerID{}
+	for _, p := range g.Players {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestSeatingPolicies(t *testing.T) {
+	g := getSeatingGame()
+	g.SetOptions(Options{Seating: SeatingFixed})
+	for i := 0; i < 3; i++ {
+		g.SeatPlayers()
+		if ids := seatedIDs(g); !reflect.DeepEqual(ids, []PlayerID{1, 2, 3, 4}) {
+			t.Errorf("Fixed seating moved players: %v", ids)
+		}
+	}
+
+	g = getSeatingGame()
+	g.SetOptions(Options{Seating: SeatingRotated})
+	expected := [][]PlayerID{{1, 2, 3, 4}, {2, 3, 4, 1}, {3, 4, 1, 2}, {4, 1, 2, 3}, {1, 2, 3, 4}}
+	for _, e := range expected {
+		g.SeatPlayers()
+		if ids := seatedIDs(g); !reflect.DeepEqual(ids, e) {
+			t.Errorf("Expected rotated seating %v, got: %v", e, ids)
+		}
+	}
+}
+
+func TestSetSeats(t *testing.T) {
+	g := getSeatingGame()
+	g.SetOptions(Options{Seating: SeatingRotated})
+
+	if err := g.SetSeats([]PlayerID{3, 1, 4, 2}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	g.SeatPlayers()
+	g.SeatPlayers()
+	if ids := seatedIDs(g); !reflect.DeepEqual(ids, []PlayerID{3, 1, 4, 2}) {
+		t.Errorf("Expected the seats given, got: %v", ids)
+	}
+
+	invalid := [][]PlayerID{
+		{1, 2, 3},
+		{1, 2, 3, 5},
+		{1, 2, 3, 3},
+	}
+	for _, seats := range invalid {
+		if err := g.SetSeats(seats); err == nil {
+			t.Errorf("Expected an error seating %v", seats)
+		}
+	}
+}
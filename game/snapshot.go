@@ -0,0 +1,166 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/boardgamesai/games/util"
+)
+
+// Snapshot is a serializable checkpoint of a game in progress, which the game can be resumed from.
+type Snapshot struct {
+	Game        Name
+	Players     []Player // In seat order
+	Events      []LoggedEvent
+	Places      []Place
+	Substitutes []PlayerID
+	Rand        []byte          // State of the game's random number generator
+	Position    string          `json:",omitempty"` // Custom starting position, if any
+	DrawOffer   PlayerID        `json:",omitempty"` // Who has a draw offer open, if anyone
+	Plies       int             `json:",omitempty"` // Moves counted toward the MaxPlies option so far
+	State       json.RawMessage // Whatever else the specific game needs, e.g. the board and whose turn it is
+}
+
+// LoggedEvent is an Event along with who it's shown to and who has seen it, which Event itself
+// leaves out of its JSON since it's what we send to players.
+type LoggedEvent struct {
+	Type string
+	Data json.RawMessage
+	Show map[PlayerID]bool
+	Seen map[PlayerID]bool
+}
+
+// CheckpointObserver can be implemented by an Observer that also wants every checkpoint as it's
+// taken, e.g. to write it to disk.
+type CheckpointObserver interface {
+	OnCheckpoint(s *Snapshot)
+}
+
+// Rand is the game's source of randomness for dealing, rolling and the like. Its state is saved
+// in snapshots, so a resumed game carries on with the same luck it would have had.
+func (g *Game[P, B, C]) Rand() *util.Rand {
+	if g.rng == nil {
+		g.rng = util.NewRand(util.RandSeed())
+	}
+	return g.rng
+}
+
+// Checkpoint takes a snapshot of the game, which games do at the points they're able to resume from.
+// state is whatever the game needs beyond what's tracked here, and must serialize to JSON.
+func (g *Game[P, B, C]) Checkpoint(state interface{}) error {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	rng, err := g.Rand().MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	s := Snapshot{
+		Game:        g.Name,
+		Players:     []Player{},
+		Events:      []LoggedEvent{},
+		Places:      append([]Place{}, g.places...),
+		Substitutes: append([]PlayerID{}, g.substitutes...),
+		Rand:        rng,
+		Position:    g.position,
+		DrawOffer:   g.drawOffer,
+		Plies:       g.plies,
+		State:       stateJSON,
+	}
+	for _, p := range g.Players {
+		s.Players = append(s.Players, *p.BasePlayer())
+	}
+	for _, e := range g.EventLog {
+		s.Events = append(s.Events, LoggedEvent{
+			Type: e.Type,
+			Data: e.Data,
+			Show: copyIDs(e.Show),
+			Seen: copyIDs(e.Seen),
+		})
+	}
+
+	g.checkpoint = &s
+
+	for _, o := range g.observers {
+		if co, ok := o.(CheckpointObserver); ok {
+			co.OnCheckpoint(&s)
+		}
+	}
+
+	return nil
+}
+
+// LastCheckpoint returns the most recent snapshot taken while playing, or nil if there isn't one.
+// If an AI crashed, this is the point to resume from once it's fixed.
+func (g *Game[P, B, C]) LastCheckpoint() *Snapshot {
+	return g.checkpoint
+}
+
+// Restore loads everything tracked here from a snapshot, and unmarshals the game-specific part into state.
+// Players are matched up by ID, so they need the same IDs they had when the snapshot was taken.
+func (g *Game[P, B, C]) Restore(s *Snapshot, state interface{}) error {
+	if s.Game != g.Name {
+		return fmt.Errorf("snapshot is for %s, not %s", s.Game, g.Name)
+	}
+	if len(s.Players) != len(g.Players) {
+		return fmt.Errorf("snapshot has %d players, game has %d", len(s.Players), len(g.Players))
+	}
+
+	// Put the players back in the seats they had.
+	seated := make([]P, len(g.Players))
+	for i, sp := range s.Players {
+		found := false
+		for _, p := range g.Players {
+			if p.BasePlayer().ID == sp.ID {
+				seated[i] = p
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no player with ID %d to restore", sp.ID)
+		}
+	}
+
+	if err := json.Unmarshal(s.State, state); err != nil {
+		return err
+	}
+
+	rng := util.NewRand(0)
+	if err := rng.UnmarshalBinary(s.Rand); err != nil {
+		return err
+	}
+
+	// The players are relaunched, so they start over without having seen anything.
+	g.EventLog.Clear()
+	for _, e := range s.Events {
+		g.EventLog = append(g.EventLog, Event{
+			Type: e.Type,
+			Data: e.Data,
+			Show: copyIDs(e.Show),
+			Seen: map[PlayerID]bool{},
+		})
+	}
+
+	copy(g.Players, seated)
+	g.places = append([]Place{}, s.Places...)
+	g.substitutes = append([]PlayerID{}, s.Substitutes...)
+	g.rng = rng
+	g.position = s.Position
+	g.drawOffer = s.DrawOffer
+	g.plies = s.Plies
+	g.checkpoint = s
+
+	return nil
+}
+
+func copyIDs(m map[PlayerID]bool) map[PlayerID]bool {
+	c := map[PlayerID]bool{}
+	for id, v := range m {
+		c[id] = v
+	}
+	return c
+}
@@ -0,0 +1,252 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// StreamBuffer is how many messages a slow client can fall behind before it's dropped.
+const StreamBuffer = 256
+
+// StreamedEvent is a public event as it's streamed, with how it reads if the stream can decode it.
+type StreamedEvent struct {
+	Event
+	Text string `json:",omitempty"`
+}
+
+// StreamStart is sent when a game starts.
+type StreamStart struct {
+	Game    Name
+	Players []*Player
+}
+
+// StreamEnd is sent when a game ends.
+type StreamEnd struct {
+	Places      []Place
+	Termination Termination
+	Error       string `json:",omitempty"`
+}
+
+// StreamSnapshot is sent to each client when it connects, to catch it up on the game so far.
+type StreamSnapshot struct {
+	*StreamStart // Nil until a game has started
+	Events       []StreamedEvent
+	End          *StreamEnd `json:",omitempty"` // Nil until the game's over
+}
+
+// Stream is an Observer that streams a game live to browsers over Server-Sent Events, showing only public
+// events, so nothing hidden, like a Hearts hand or a Liar's Dice roll, gets out. Clients get a snapshot
+// event with the game so far when they connect, then start, event and end events as the game goes on.
+// The data of each is JSON, as StreamSnapshot, StreamStart, StreamedEvent and StreamEnd respectively,
+// and a close event says there's nothing more to come.
+// One stream can follow several games in a row, each one's start event meaning a clean slate. Opened
+// in a browser, it serves a page that follows the stream.
+type Stream struct {
+	NopObserver
+	decode func(e Event) fmt.Stringer
+
+	mu       sync.Mutex
+	snapshot StreamSnapshot
+	clients  map[chan []byte]bool
+	closed   bool
+}
+
+// NewStream makes a stream, which uses decode, if it's given, to say how each event reads.
+func NewStream(decode func(e Event) fmt.Stringer) *Stream {
+	return &Stream{
+		decode:   decode,
+		snapshot: StreamSnapshot{Events: []StreamedEvent{}},
+		clients:  map[chan []byte]bool{},
+	}
+}
+
+func (s *Stream) OnStart(name Name, players []*Player) {
+	start := StreamStart{Game: name, Players: players}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot = StreamSnapshot{StreamStart: &start, Events: []StreamedEvent{}}
+	s.send("start", start)
+}
+
+func (s *Stream) OnEvent(e Event) {
+	if !e.Show[ShowAll] {
+		return
+	}
+
+	streamed := StreamedEvent{Event: e}
+	if s.decode != nil {
+		if text := s.decode(e); text != nil {
+			streamed.Text = text.String()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot.Events = append(s.snapshot.Events, streamed)
+	s.send("event", streamed)
+}
+
+func (s *Stream) OnEnd(places []Place, termination Termination, err error) {
+	end := StreamEnd{Places: places, Termination: termination}
+	if err != nil {
+		end.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot.End = &end
+	s.send("end", end)
+}
+
+// Close sends everyone a close event and disconnects them, once there's nothing more to stream, so
+// browsers know not to reconnect. Clients that connect after get the snapshot and are closed straight away.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.send("close", nil)
+	for client := range s.clients {
+		close(client)
+		delete(s.clients, client)
+	}
+}
+
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(streamPage))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming isn't supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	s.mu.Lock()
+	snapshot, err := streamMessage("snapshot", s.snapshot)
+	client := make(chan []byte, StreamBuffer)
+	if s.closed {
+		closing, _ := streamMessage("close", nil)
+		client <- closing
+		close(client)
+	} else {
+		s.clients[client] = true
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		return
+	}
+	w.Write(snapshot)
+	flusher.Flush()
+
+	for {
+		select {
+		case message, ok := <-client:
+			if !ok {
+				return
+			}
+			w.Write(message)
+			flusher.Flush()
+		case <-r.Context().Done():
+			s.mu.Lock()
+			if s.clients[client] {
+				delete(s.clients, client)
+				close(client)
+			}
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// send passes a message on to every client, dropping any that have fallen too far behind, since
+// they'd have a gap in the game otherwise. They can reconnect for a fresh snapshot. It's called
+// with s.mu held.
+func (s *Stream) send(eventType string, data interface{}) {
+	message, err := streamMessage(eventType, data)
+	if err != nil {
+		return
+	}
+
+	for client := range s.clients {
+		select {
+		case client <- message:
+		default:
+			delete(s.clients, client)
+			close(client)
+		}
+	}
+}
+
+func streamMessage(eventType string, data interface{}) ([]byte, error) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", eventType, dataJSON)), nil
+}
+
+// streamPage follows the stream it's served from, listing the events as they come.
+const streamPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>boardgames.ai live</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+#events { font-family: monospace; }
+.end { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1 id="title">Waiting for a game to start...</h1>
+<p id="players"></p>
+<ol id="events"></ol>
+<div id="end" class="end"></div>
+<script>
+const source = new EventSource(location.href);
+const $ = id => document.getElementById(id);
+
+function start(s) {
+	$("title").textContent = s.Game ? s.Game : "Waiting for a game to start...";
+	$("players").textContent = (s.Players || []).map(p => p.Name + " (ID: " + p.ID + ")").join(", ");
+	$("events").innerHTML = "";
+	$("end").textContent = "";
+}
+
+function addEvent(e) {
+	const li = document.createElement("li");
+	li.textContent = e.Text || e.Type + " " + JSON.stringify(e.Data);
+	$("events").appendChild(li);
+}
+
+function end(e) {
+	const places = e.Places.map(p => p.Rank + ". " + p.Player.Name + (p.Tie ? " (tie)" : ""));
+	$("end").textContent = "Game over (" + e.Termination + "): " + places.join(", ") + (e.Error ? " - " + e.Error : "");
+}
+
+source.addEventListener("snapshot", m => {
+	const s = JSON.parse(m.data);
+	start(s);
+	s.Events.forEach(addEvent);
+	if (s.End) end(s.End);
+});
+source.addEventListener("start", m => start(JSON.parse(m.data)));
+source.addEventListener("event", m => addEvent(JSON.parse(m.data)));
+source.addEventListener("end", m => end(JSON.parse(m.data)));
+source.addEventListener("close", () => source.close());
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// readStreamMessage reads the next event off an SSE stream, decoding its data into v if it's given.
+func readStreamMessage(t *testing.T, r *bufio.Reader, v interface{}) string {
+	eventType := ""
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Unexpected error reading stream: %s", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return eventType
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && v != nil:
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), v); err != nil {
+				t.Fatalf("Couldn't decode %q: %s", line, err)
+			}
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	stream := NewStream(nil)
+	server := httptest.NewServer(stream)
+	defer server.Close()
+
+	public := func(data string) Event {
+		return Event{Type: "move", Data: json.RawMessage(data), Show: map[PlayerID]bool{ShowAll: true}}
+	}
+	hidden := Event{Type: "deal", Data: json.RawMessage(`{"Hand":"secret"}`), Show: map[PlayerID]bool{1: true}}
+
+	stream.OnStart(TicTacToe, []*Player{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}})
+	stream.OnEvent(public(`{"ID":1}`))
+	stream.OnEvent(hidden)
+
+	// Joining mid-game, we're caught up on the public events so far
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	r := bufio.NewReader(resp.Body)
+
+	snapshot := StreamSnapshot{}
+	if eventType := readStreamMessage(t, r, &snapshot); eventType != "snapshot" {
+		t.Fatalf("Expected a snapshot first, got %s", eventType)
+	}
+	if snapshot.StreamStart == nil || snapshot.Game != TicTacToe || len(snapshot.Players) != 2 {
+		t.Errorf("Expected the game and players in the snapshot, got: %+v", snapshot)
+	}
+	if len(snapshot.Events) != 1 || string(snapshot.Events[0].Data) != `{"ID":1}` {
+		t.Errorf("Expected only the public event in the snapshot, got: %+v", snapshot.Events)
+	}
+
+	// Then the rest as it happens, still only what's public
+	stream.OnEvent(hidden)
+	stream.OnEvent(public(`{"ID":2}`))
+	e := StreamedEvent{}
+	if eventType := readStreamMessage(t, r, &e); eventType != "event" || string(e.Data) != `{"ID":2}` {
+		t.Errorf("Expected the public event, got %s: %+v", eventType, e)
+	}
+
+	stream.OnEnd([]Place{{Player: Player{ID: 2}, Rank: 1}, {Player: Player{ID: 1}, Rank: 2}}, TerminationNormal, nil)
+	end := StreamEnd{}
+	if eventType := readStreamMessage(t, r, &end); eventType != "end" || len(end.Places) != 2 || end.Termination != TerminationNormal {
+		t.Errorf("Expected the end of the game, got %s: %+v", eventType, end)
+	}
+
+	stream.Close()
+	if eventType := readStreamMessage(t, r, nil); eventType != "close" {
+		t.Errorf("Expected the stream to close, got %s", eventType)
+	}
+	if _, err := r.ReadString('\n'); err == nil {
+		t.Errorf("Expected the connection to be closed")
+	}
+
+	// Browsers get a page that follows the stream
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Accept", "text/html")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("Expected a page, got %s", resp.Header.Get("Content-Type"))
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import "fmt"
+
+const EventTypeSubstitute = "substitute"
+
+// EventSubstitute is logged when a disqualified player's seat is taken over by a fallback AI.
+type EventSubstitute struct {
+	ID   PlayerID
+	Type DQType
+	Msg  string
+}
+
+func (e EventSubstitute) String() string {
+	return fmt.Sprintf("ID %d disqualified (%s): %s, fallback AI takes over", e.ID, e.Type, e.Msg)
+}
+
+// Substitute hands a disqualified player's seat to a fallback AI, if the options allow it.
+// It returns false if err isn't a DQ or substitutes aren't allowed, meaning the game should end.
+func (g *Game[P, B, C]) Substitute(p P, err error) bool {
+	dqErr, ok := err.(*DQError)
+	if !ok || !g.options.Substitute {
+		return false
+	}
+
+	player := p.BasePlayer()
+	g.substitutes = append(g.substitutes, player.ID)
+
+	// They're out of the game, no need to keep their process around.
+	if player.Runnable != nil {
+		player.CleanUp()
+	}
+
+	e := EventSubstitute{
+		ID:   player.ID,
+		Type: dqErr.Type,
+		Msg:  dqErr.Msg,
+	}
+	g.AddAll(e)
+
+	return true
+}
+
+func (g *Game[P, B, C]) IsSubstitute(id PlayerID) bool {
+	return g.substituteIndex(id) >= 0
+}
+
+func (g *Game[P, B, C]) substituteIndex(id PlayerID) int {
+	for i, substituteID := range g.substitutes {
+		if substituteID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// demoteSubstitutes moves players who were replaced by a fallback AI to the bottom of places, which
+// must already be sorted. The earliest DQ finishes last, and everyone else is re-ranked above them.
+func (g *Game[P, B, C]) demoteSubstitutes(places []Place) []Place {
+	kept := []Place{}
+	demoted := map[PlayerID]Place{}
+
+	for _, place := range places {
+		if g.IsSubstitute(place.Player.ID) {
+			demoted[place.Player.ID] = place
+		} else {
+			kept = append(kept, place)
+		}
+	}
+
+	// Players who shared a rank before still share one, but the rank itself may have changed.
+	newPlaces := make([]Place, len(kept))
+	for i, place := range kept {
+		if i > 0 && place.Rank == kept[i-1].Rank {
+			place.Rank = newPlaces[i-1].Rank
+		} else {
+			place.Rank = i + 1
+		}
+		newPlaces[i] = place
+	}
+	for i := range newPlaces {
+		newPlaces[i].Tie = (i > 0 && newPlaces[i].Rank == newPlaces[i-1].Rank) ||
+			(i < len(newPlaces)-1 && newPlaces[i].Rank == newPlaces[i+1].Rank)
+	}
+
+	// Go backwards so the most recent DQ is placed highest.
+	for i := len(g.substitutes) - 1; i >= 0; i-- {
+		place, ok := demoted[g.substitutes[i]]
+		if !ok {
+			continue
+		}
+
+		place.Rank = len(newPlaces) + 1
+		place.Tie = false
+		newPlaces = append(newPlaces, place)
+	}
+
+	return newPlaces
+}
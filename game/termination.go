@@ -0,0 +1,127 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Termination records how a game ended.
+type Termination string
+
+const (
+	TerminationNormal      = Termination("normal")      // Played to completion
+	TerminationDQ          = Termination("dq")          // Ended early because a player was disqualified
+	TerminationError       = Termination("error")       // Ended early because of an error on our side
+	TerminationAborted     = Termination("aborted")     // Cancelled or timed out by the caller before finishing
+	TerminationResign      = Termination("resign")      // A player resigned
+	TerminationDraw        = Termination("draw")        // The players agreed to a draw
+	TerminationAdjudicated = Termination("adjudicated") // Decided by the engine before it was played out
+)
+
+const EventTypeAbort = "abort"
+
+// EventAbort is logged when the caller cancels a game before it finishes.
+type EventAbort struct {
+	Msg string
+}
+
+func (e EventAbort) String() string {
+	return fmt.Sprintf("game aborted: %s", e.Msg)
+}
+
+func (g *Game[P, B, C]) Termination() Termination {
+	return g.termination
+}
+
+// Finish records how the game ended, given the error returned from playing it.
+// If ctx is done the game is marked aborted and no one is placed.
+func (g *Game[P, B, C]) Finish(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		g.places = []Place{}
+		g.termination = TerminationAborted
+		g.AddAll(EventAbort{Msg: ctx.Err().Error()})
+		err = fmt.Errorf("game aborted: %w", ctx.Err())
+		g.end(err)
+		return err
+	}
+
+	g.termination = g.terminationFor(err)
+	g.end(err)
+	return err
+}
+
+// terminationFor also takes into account a player resigning or agreeing to a draw, or the engine
+// adjudicating.
+func (g *Game[P, B, C]) terminationFor(err error) Termination {
+	t := terminationFor(err)
+	if t == TerminationNormal && g.endedBy != "" {
+		return g.endedBy
+	}
+	return t
+}
+
+// terminationFor says how a game ended, given the error returned from playing it.
+func terminationFor(err error) Termination {
+	switch e := err.(type) {
+	case nil:
+		return TerminationNormal
+	case DQError:
+		return dqTermination(e)
+	case *DQError:
+		return dqTermination(*e)
+	default:
+		return TerminationError
+	}
+}
+
+// dqTermination treats a desync as an error on our side, since the AI didn't do anything wrong.
+func dqTermination(e DQError) Termination {
+	if e.Type == DQTypeDesync {
+		return TerminationError
+	}
+	return TerminationDQ
+}
+
+// CommonEvent decodes the events logged by the game package itself, rather than by
+// a specific game. It returns nil if e isn't one of them.
+func CommonEvent(e Event) fmt.Stringer {
+	var eStr fmt.Stringer
+
+	switch e.Type {
+	case EventTypeSubstitute:
+		ce := EventSubstitute{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeAbort:
+		ce := EventAbort{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeResign:
+		ce := EventResign{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeDrawOffer:
+		ce := EventDrawOffer{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeDraw:
+		ce := EventDraw{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeAnnotation:
+		ce := EventAnnotation{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeAdjudication:
+		ce := EventAdjudication{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeWarmUp:
+		ce := EventWarmUp{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	}
+
+	return eStr
+}
@@ -0,0 +1,83 @@
+package game
+
+// View is whose eyes the event log is seen through.
+type View struct {
+	Player   PlayerID // Whose view it is, or ShowAll for a spectator who only sees public events
+	Full     bool     // Everything, hidden or not
+	Revealed bool     // Along with whatever was revealed to them once the game was over
+}
+
+var (
+	ViewFull   = View{Full: true}
+	ViewPublic = View{Player: ShowAll}
+)
+
+// ViewPlayer is the log exactly as the player saw it while the game was going on.
+func ViewPlayer(id PlayerID) View {
+	return View{Player: id}
+}
+
+// ViewRevealed is the player's view, or a spectator's for ShowAll, along with anything the reveal
+// policy showed them once the game was over.
+func ViewRevealed(id PlayerID) View {
+	return View{Player: id, Revealed: true}
+}
+
+// Reveal decides who gets to see hidden events, like other players' cards, once the game is over.
+type Reveal string
+
+const (
+	RevealPlayers = Reveal("players") // Players see everything, spectators still only see public events
+	RevealAll     = Reveal("all")     // Everyone sees everything
+	RevealNone    = Reveal("none")    // Hidden events stay hidden
+)
+
+// View returns the events seen through v, in order, taking into account anything revealed at the
+// end of the game if v asks for it. Unlike NewForPlayer, it doesn't mark anything seen.
+func (el *EventLog) View(v View, revealed Reveal) []Event {
+	if v.Full {
+		return *el
+	}
+
+	events := []Event{}
+	for _, e := range *el {
+		if e.Show[ShowAll] || e.Show[v.Player] || (v.Revealed && isRevealed(e, v.Player, revealed)) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// RevealedTo returns the events that were hidden from the player but have been revealed to them since.
+func (el *EventLog) RevealedTo(playerID PlayerID, revealed Reveal) []Event {
+	events := []Event{}
+	for _, e := range el.HiddenFrom(playerID) {
+		if isRevealed(e, playerID, revealed) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// isRevealed says whether a hidden event has been revealed to the player, or to spectators for ShowAll.
+func isRevealed(e Event, playerID PlayerID, revealed Reveal) bool {
+	if e.private() {
+		// Annotations stay hidden from opponents, even with everything else revealed
+		return revealed == RevealAll && playerID == ShowAll
+	}
+	return revealed == RevealAll || (revealed == RevealPlayers && playerID != ShowAll)
+}
+
+// RawEventsFor returns the events seen through v.
+func (g *Game[P, B, C]) RawEventsFor(v View) []Event {
+	return g.EventLog.View(v, g.revealed)
+}
+
+// reveal applies the reveal policy from the options, now that the game is over.
+func (g *Game[P, B, C]) reveal() {
+	r := g.options.Reveal
+	if r == "" {
+		r = RevealPlayers
+	}
+	g.revealed = r
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func eventTypes(events []Event) string {
+	types := []string{}
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return strings.Join(types, ",")
+}
+
+func TestView(t *testing.T) {
+	tests := []struct {
+		reveal   Reveal
+		view     View
+		expected string
+	}{
+		{"", ViewFull, "test1,test2,test3"},
+		{"", ViewPublic, "test1"},
+		{"", ViewPlayer(1), "test1,test3"},
+		{"", ViewPlayer(2), "test1,test2"},
+		{"", ViewRevealed(1), "test1,test3"},
+		{RevealNone, ViewRevealed(1), "test1,test3"},
+		{RevealNone, ViewFull, "test1,test2,test3"},
+		{RevealPlayers, ViewPlayer(1), "test1,test3"},
+		{RevealPlayers, ViewRevealed(1), "test1,test2,test3"},
+		{RevealPlayers, ViewRevealed(ShowAll), "test1"},
+		{RevealAll, ViewPublic, "test1"},
+		{RevealAll, ViewRevealed(ShowAll), "test1,test2,test3"},
+	}
+
+	for _, test := range tests {
+		l, err := getEventLog()
+		if err != nil {
+			t.Fatalf("Adding events returned error: %s", err)
+		}
+		if types := eventTypes(l.View(test.view, test.reveal)); types != test.expected {
+			t.Errorf("Reveal %q, view %+v: expected %s, got: %s", test.reveal, test.view, test.expected, types)
+		}
+	}
+}
+
+func TestViewDoesntMarkSeen(t *testing.T) {
+	l, err := getEventLog()
+	if err != nil {
+		t.Fatalf("Adding events returned error: %s", err)
+	}
+
+	first := eventTypes(l.View(ViewPlayer(1), ""))
+	if second := eventTypes(l.View(ViewPlayer(1), "")); first != second {
+		t.Errorf("Viewing twice gave different events: %s then %s", first, second)
+	}
+	if events := l.NewForPlayer(1); len(events) != 2 {
+		t.Errorf("Expected 2 new events for player 1 after viewing, got: %d", len(events))
+	}
+}
+
+func TestRevealedTo(t *testing.T) {
+	tests := []struct {
+		reveal   Reveal
+		expected string
+	}{
+		{"", ""},
+		{RevealNone, ""},
+		{RevealPlayers, "test2"},
+		{RevealAll, "test2"},
+	}
+
+	for _, test := range tests {
+		l, err := getEventLog()
+		if err != nil {
+			t.Fatalf("Adding events returned error: %s", err)
+		}
+		if types := eventTypes(l.RevealedTo(1, test.reveal)); types != test.expected {
+			t.Errorf("Reveal %q: expected %q revealed to player 1, got: %q", test.reveal, test.expected, types)
+		}
+	}
+}
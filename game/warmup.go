@@ -0,0 +1,76 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const EventTypeWarmUp = "warmup"
+
+// MessageWarmUp is sent to each player once everyone's set up, with the WarmUp option on, so they can
+// build opening books, tables and the like before the first move. It has its own time limit, TimeLimit,
+// rather than the usual one for responses, and launching and compiling the player doesn't count against it.
+// In the Go drivers, an AI gets it by implementing WarmUp(ctx, state), where ctx is done at AITimeLimit,
+// a little before the time's up, and it should return as soon as it is.
+type MessageWarmUp struct {
+	TimeLimit time.Duration
+}
+
+// maxWarmUpMargin caps how much of the warm-up time the Go drivers keep back for replying.
+const maxWarmUpMargin = 500 * time.Millisecond
+
+// AITimeLimit is how long the Go drivers let an AI warm up: TimeLimit less a tenth of it, or less half a
+// second if that's shorter, so the driver's reply still gets back to the engine in time.
+func (m MessageWarmUp) AITimeLimit() time.Duration {
+	return m.TimeLimit - min(m.TimeLimit/10, maxWarmUpMargin)
+}
+
+// EventWarmUp is logged for each player who warms up, with how long they took.
+type EventWarmUp struct {
+	ID      PlayerID
+	Elapsed time.Duration
+}
+
+func (e EventWarmUp) String() string {
+	return fmt.Sprintf("ID %d warmed up in %s", e.ID, e.Elapsed.Round(time.Millisecond))
+}
+
+type responseTimeoutKey struct{}
+
+// WithResponseTimeout gives messages sent with the returned context their own time limit,
+// instead of PlayerResponseTimeout.
+func WithResponseTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, responseTimeoutKey{}, timeout)
+}
+
+// ResponseTimeout is how long a player has to respond to a message sent with ctx.
+func ResponseTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(responseTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return time.Second * PlayerResponseTimeout
+}
+
+// WarmUp sends the player a MessageWarmUp, if the WarmUp option is set, and logs how long they took.
+// Players should be warmed up one at a time so they don't slow each other down. The error is a
+// DQError if they didn't acknowledge it in time.
+func (g *Game[P, B, C]) WarmUp(ctx context.Context, p P) error {
+	player := p.BasePlayer()
+	if g.options.WarmUp <= 0 || g.IsSubstitute(player.ID) {
+		return nil
+	}
+
+	c := g.BaseComms()
+	start := time.Now()
+	err := c.SendMessageNoResponse(WithResponseTimeout(ctx, g.options.WarmUp), player, MessageWarmUp{TimeLimit: g.options.WarmUp})
+	switch e := err.(type) {
+	case DQError:
+		return g.AddDQErrorID(&e, player.ID)
+	case *DQError:
+		return g.AddDQErrorID(e, player.ID)
+	case nil:
+		g.AddNone(EventWarmUp{ID: player.ID, Elapsed: time.Since(start)})
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type timedRunnable struct {
+	RunnablePlayerMock
+	timeouts []time.Duration
+	err      error
+}
+
+func (r *timedRunnable) SendMessageNoResponse(ctx context.Context, message interface{}) error {
+	r.timeouts = append(r.timeouts, ResponseTimeout(ctx))
+	return r.err
+}
+
+func TestWarmUp(t *testing.T) {
+	g := getConcessionGame("tictactoe")
+	r1 := &timedRunnable{}
+	r2 := &timedRunnable{err: DQError{Type: DQTypeTimeout, Msg: "too slow"}}
+	g.Players[0].Runnable = r1
+	g.Players[1].Runnable = r2
+
+	// Nothing's sent without the option
+	if err := g.WarmUp(context.Background(), g.Players[0]); err != nil || len(r1.timeouts) != 0 {
+		t.Fatalf("Expected no warm-up, got: %v %+v", err, r1.timeouts)
+	}
+
+	g.SetOptions(Options{WarmUp: time.Minute})
+	if err := g.WarmUp(context.Background(), g.Players[0]); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(r1.timeouts) != 1 || r1.timeouts[0] != time.Minute {
+		t.Errorf("Expected the warm-up to get its own timeout, got: %+v", r1.timeouts)
+	}
+	events := g.EventLog
+	if len(events) != 1 || events[0].Type != EventTypeWarmUp || len(events[0].Show) != 0 {
+		t.Errorf("Expected a warmup event shown to no one, got: %+v", events)
+	}
+
+	err := g.WarmUp(context.Background(), g.Players[1])
+	if dqErr, ok := err.(*DQError); !ok || dqErr.ID != 2 || dqErr.Type != DQTypeTimeout {
+		t.Errorf("Expected ID 2 to time out, got: %v", err)
+	}
+	if len(g.EventLog) != 1 {
+		t.Errorf("Expected no warmup event for a timeout, got: %+v", g.EventLog)
+	}
+
+	if timeout := ResponseTimeout(context.Background()); timeout != PlayerResponseTimeout*time.Second {
+		t.Errorf("Expected the default response timeout, got: %s", timeout)
+	}
+}
+
+func TestWarmUpAITimeLimit(t *testing.T) {
+	tests := []struct {
+		limit    time.Duration
+		expected time.Duration
+	}{
+		{time.Minute, time.Minute - 500*time.Millisecond},
+		{time.Second, 900 * time.Millisecond},
+		{5 * time.Second, 4500 * time.Millisecond},
+		{0, 0},
+	}
+
+	for _, test := range tests {
+		if got := (MessageWarmUp{TimeLimit: test.limit}).AITimeLimit(); got != test.expected {
+			t.Errorf("Time limit %s: expected %s, got: %s", test.limit, test.expected, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package driver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -42,15 +43,31 @@ func (d *Driver) Run() {
 			response, err = d.handlePass(message.Data)
 		case "play":
 			response, err = d.handlePlay(message.Data)
+		case "warmup":
+			response, err = d.handleWarmUp(message.Data)
+		case "ponder":
+			response, err = d.handlePonder(message.Data)
+		case "gameover":
+			response, err = d.handleGameOver(message.Data)
 		default:
 			log.Fatalf("Unknown message type: %s", message.Type)
 		}
 
+		if dqErr, ok := err.(*game.DQError); ok {
+			// Let the engine know why rather than crashing, e.g. on a desync
+			d.PrintErrorResponse(dqErr)
+			continue
+		}
 		if err != nil {
 			log.Fatalf("Error handling message: %+v err: %s", message, err)
 		}
 
 		d.PrintResponse(response)
+
+		// Nothing comes after the game over message, and we're about to be shut down
+		if message.Type == "gameover" {
+			return
+		}
 	}
 }
 
@@ -77,12 +94,18 @@ func (d *Driver) handlePass(message []byte) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
+	if dqErr := d.CheckHash(d.state.ID, passMessage.Hash, d.state.Hand.Hash()); dqErr != nil {
+		return []byte{}, dqErr
+	}
 
 	d.state.PassDirection = passMessage.Direction
 
 	// Remove these cards from their hand right away
 	// No need to sort, because cards will be passed to us and then we'll sort
 	move := d.ai.GetPass(*d.state)
+	if ai, ok := d.ai.(annotatingAI); ok {
+		d.Annotate(ai.Annotation())
+	}
 	for _, c := range move.Cards {
 		d.state.Hand.Remove(c)
 	}
@@ -105,6 +128,9 @@ func (d *Driver) handlePlay(message []byte) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
+	if dqErr := d.CheckHash(d.state.ID, playMessage.Hash, d.state.Hand.Hash()); dqErr != nil {
+		return []byte{}, dqErr
+	}
 
 	d.state.Trick = playMessage.Trick
 	for _, c := range d.state.Trick {
@@ -114,6 +140,9 @@ func (d *Driver) handlePlay(message []byte) ([]byte, error) {
 	}
 
 	move := d.ai.GetPlay(*d.state)
+	if ai, ok := d.ai.(annotatingAI); ok {
+		d.Annotate(ai.Annotation())
+	}
 
 	if !d.state.HeartsBroken && move.Card.Suit == card.Hearts {
 		d.state.HeartsBroken = true
@@ -128,6 +157,57 @@ func (d *Driver) handlePlay(message []byte) ([]byte, error) {
 	return moveJSON, nil
 }
 
+func (d *Driver) handleWarmUp(message []byte) ([]byte, error) {
+	warmUpMessage := game.MessageWarmUp{}
+	if err := json.Unmarshal(message, &warmUpMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if ai, ok := d.ai.(warmingUpAI); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), warmUpMessage.AITimeLimit())
+		defer cancel()
+		ai.WarmUp(ctx, *d.state)
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *Driver) handlePonder(message []byte) ([]byte, error) {
+	ponderMessage := game.MessagePonder{}
+	if err := json.Unmarshal(message, &ponderMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if err := d.processNewEvents(ponderMessage.NewEvents); err != nil {
+		return []byte{}, err
+	}
+
+	if ai, ok := d.ai.(ponderingAI); ok {
+		state := *d.state
+		d.StartPondering(func(ctx context.Context) {
+			ai.Ponder(ctx, state)
+		})
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *Driver) handleGameOver(message []byte) ([]byte, error) {
+	gameOverMessage := game.MessageGameOver{}
+	err := json.Unmarshal(message, &gameOverMessage)
+	if err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	err = d.processNewEvents(gameOverMessage.NewEvents)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if ai, ok := d.ai.(gameOverAI); ok {
+		ai.GameOver(*d.state, gameOverMessage)
+	}
+	return d.OkJSON(), nil
+}
+
 func (d *Driver) processNewEvents(events []game.Event) error {
 	for _, e := range events {
 
@@ -1,9 +1,15 @@
 package hearts
 
-import "github.com/boardgamesai/games/game/elements/card"
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
 
 type AIComms interface {
-	Setup(p *Player, players []*Player) error
-	GetPassMove(p *Player, direction PassDirection) (PassMove, error)
-	GetPlayMove(p *Player, trick []card.Card) (PlayMove, error)
+	Setup(ctx context.Context, p *Player, players []*Player) error
+	GetPassMove(ctx context.Context, p *Player, direction PassDirection, hash string, state *PlayerState) (PassMove, error)
+	GetPlayMove(ctx context.Context, p *Player, trick []card.Card, hash string, legalMoves []PlayMove, state *PlayerState) (PlayMove, error)
+	GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error
 }
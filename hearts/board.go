@@ -3,9 +3,19 @@ package hearts
 import "github.com/boardgamesai/games/game/elements/card"
 
 type Board struct {
-	Deck   card.StandardDeck
-	Hands  map[*Player]*Hand
-	Scores *Scores
+	Deck          card.StandardDeck
+	Hands         map[*Player]*Hand
+	Scores        *Scores
+	PassDirection PassDirection   // This round's
+	RoundScores   map[*Player]int // Points taken so far this round
+
+	// Where we are in the round, so it can pick up from any pass or play.
+	Passes       map[*Player]PassMove // Passes chosen but not handed over yet, nil unless we're passing
+	Trick        []card.Card          // Cards played so far in the current trick
+	Leader       *Player              // Who led the current trick, nil until the round's first lead
+	TrickCount   int                  // Tricks finished this round
+	HeartsBroken bool
+	TookPoints   map[*Player]bool // Who's taken points this round, to spot a moonshot
 }
 
 func NewBoard(players []*Player) *Board {
@@ -15,8 +25,11 @@ func NewBoard(players []*Player) *Board {
 	}
 
 	return &Board{
-		Deck:   card.NewStandardDeck(),
-		Hands:  hands,
-		Scores: NewScores(),
+		Deck:        card.NewStandardDeck(),
+		Hands:       hands,
+		Scores:      NewScores(),
+		RoundScores: map[*Player]int{},
+		Trick:       []card.Card{},
+		TookPoints:  map[*Player]bool{},
 	}
 }
@@ -1,6 +1,7 @@
 package hearts
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/boardgamesai/games/game"
@@ -13,29 +14,29 @@ type Comms struct {
 
 func NewComms(g *Game) *Comms {
 	return &Comms{
-		Comms: game.Comms{
-			EventLog: &(g.EventLog),
-		},
+		Comms: g.BaseComms(),
 	}
 }
 
-func (c *Comms) Setup(p *Player, players []*Player) error {
+func (c *Comms) Setup(ctx context.Context, p *Player, players []*Player) error {
 	message := MessageSetup{
 		ID:       p.ID,
 		Position: p.Position,
 		Players:  players,
 	}
-	return p.SendMessageNoResponse(message)
+	return c.SendMessageNoResponse(ctx, &p.Player, message)
 }
 
-func (c *Comms) GetPassMove(p *Player, direction PassDirection) (PassMove, error) {
+func (c *Comms) GetPassMove(ctx context.Context, p *Player, direction PassDirection, hash string, state *PlayerState) (PassMove, error) {
 	move := PassMove{}
 
 	message := MessagePass{
 		Direction: direction,
 		NewEvents: c.NewEvents(p.ID),
+		Hash:      hash,
+		State:     state,
 	}
-	responseJSON, err := p.SendMessage(message)
+	responseJSON, err := c.SendMessage(ctx, &p.Player, message)
 	if err != nil {
 		return move, err
 	}
@@ -44,14 +45,17 @@ func (c *Comms) GetPassMove(p *Player, direction PassDirection) (PassMove, error
 	return move, err
 }
 
-func (c *Comms) GetPlayMove(p *Player, trick []card.Card) (PlayMove, error) {
+func (c *Comms) GetPlayMove(ctx context.Context, p *Player, trick []card.Card, hash string, legalMoves []PlayMove, state *PlayerState) (PlayMove, error) {
 	move := PlayMove{}
 
 	message := MessagePlay{
-		Trick:     trick,
-		NewEvents: c.NewEvents(p.ID),
+		Trick:      trick,
+		NewEvents:  c.NewEvents(p.ID),
+		Hash:       hash,
+		LegalMoves: legalMoves,
+		State:      state,
 	}
-	responseJSON, err := p.SendMessage(message)
+	responseJSON, err := c.SendMessage(ctx, &p.Player, message)
 	if err != nil {
 		return move, err
 	}
@@ -59,3 +63,7 @@ func (c *Comms) GetPlayMove(p *Player, trick []card.Card) (PlayMove, error) {
 	err = json.Unmarshal(responseJSON, &move)
 	return move, err
 }
+
+func (c *Comms) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return c.SendGameOver(ctx, &p.Player, termination, places)
+}
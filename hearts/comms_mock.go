@@ -1,18 +1,35 @@
 package hearts
 
-import "github.com/boardgamesai/games/game/elements/card"
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
 
 type CommsMock struct {
 	hands map[int][]string // Assume that hands are set up in the order we want them played
 	index int
 	count int
+	dq    map[int]bool // Positions that time out on every pass
+
+	passStates []*PlayerState // The state sent with each pass request
 }
 
-func (c *CommsMock) Setup(p *Player, players []*Player) error {
+func (c *CommsMock) Setup(ctx context.Context, p *Player, players []*Player) error {
 	return nil
 }
 
-func (c *CommsMock) GetPassMove(p *Player, direction PassDirection) (PassMove, error) {
+func (c *CommsMock) GetPassMove(ctx context.Context, p *Player, direction PassDirection, hash string, state *PlayerState) (PassMove, error) {
+	c.passStates = append(c.passStates, state)
+
+	if c.dq[p.Position] {
+		return PassMove{}, game.DQError{
+			Type: game.DQTypeTimeout,
+			Msg:  "mock timeout",
+		}
+	}
+
 	hand := c.hands[p.Position]
 	move := PassMove{
 		Cards: []card.Card{
@@ -25,7 +42,7 @@ func (c *CommsMock) GetPassMove(p *Player, direction PassDirection) (PassMove, e
 	return move, nil
 }
 
-func (c *CommsMock) GetPlayMove(p *Player, trick []card.Card) (PlayMove, error) {
+func (c *CommsMock) GetPlayMove(ctx context.Context, p *Player, trick []card.Card, hash string, legalMoves []PlayMove, state *PlayerState) (PlayMove, error) {
 	move := PlayMove{
 		Card: card.FromString(c.hands[p.Position][c.index]),
 	}
@@ -37,3 +54,7 @@ func (c *CommsMock) GetPlayMove(p *Player, trick []card.Card) (PlayMove, error)
 
 	return move, nil
 }
+
+func (c *CommsMock) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return nil
+}
@@ -1,6 +1,7 @@
 package hearts
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -23,7 +24,8 @@ type EventSetupPlayer struct {
 }
 
 type EventSetup struct {
-	Players []EventSetupPlayer
+	Players       []EventSetupPlayer
+	StartPosition string `json:",omitempty"`
 }
 
 func (e EventSetup) String() string {
@@ -31,7 +33,12 @@ func (e EventSetup) String() string {
 	for _, p := range e.Players {
 		players = append(players, fmt.Sprintf("%+v", p))
 	}
-	return "Setup " + strings.Join(players, ", ")
+
+	s := "Setup " + strings.Join(players, ", ")
+	if e.StartPosition != "" {
+		s += " from position " + e.StartPosition
+	}
+	return s
 }
 
 type EventDeal struct {
@@ -92,3 +99,39 @@ func (e EventScoreRound) String() string {
 
 	return fmt.Sprintf("Round scores: [%s] Total scores: [%s]", strings.Join(roundVals, " "), strings.Join(totalVals, " "))
 }
+
+// DecodeEvent turns a logged event back into the type it was logged as, e.g. for displaying it.
+func DecodeEvent(e game.Event) fmt.Stringer {
+	var eStr fmt.Stringer
+
+	switch e.Type {
+	case EventTypeSetup:
+		ce := EventSetup{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeDeal:
+		ce := EventDeal{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypePass:
+		ce := EventPass{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypePlay:
+		ce := EventPlay{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeScoreTrick:
+		ce := EventScoreTrick{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeScoreRound:
+		ce := EventScoreRound{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	default:
+		eStr = game.CommonEvent(e)
+	}
+
+	return eStr
+}
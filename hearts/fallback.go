@@ -0,0 +1,33 @@
+package hearts
+
+import (
+	"github.com/boardgamesai/games/game/elements/card"
+	"github.com/boardgamesai/games/util"
+)
+
+// FallbackAI plays in-process for a player who was disqualified, when substitutes are enabled.
+type FallbackAI interface {
+	GetPass(hand Hand, direction PassDirection) PassMove
+	GetPlay(hand Hand, trick []card.Card, trickCount int, heartsBroken bool) PlayMove
+}
+
+// RandomAI plays the same way as the bundled random example AI.
+type RandomAI struct{}
+
+func (ai RandomAI) GetPass(hand Hand, direction PassDirection) PassMove {
+	// Shuffle a copy of the hand and pass the first three cards.
+	cards := make([]card.Card, len(hand))
+	copy(cards, hand)
+	util.Shuffle(cards)
+
+	return PassMove{
+		Cards: cards[:3],
+	}
+}
+
+func (ai RandomAI) GetPlay(hand Hand, trick []card.Card, trickCount int, heartsBroken bool) PlayMove {
+	plays := hand.PossiblePlays(trick, trickCount, heartsBroken)
+	return PlayMove{
+		Card: plays[util.RandInt(0, len(plays)-1)],
+	}
+}
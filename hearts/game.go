@@ -1,7 +1,7 @@
 package hearts
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/boardgamesai/games/game"
@@ -11,6 +11,7 @@ import (
 
 type Game struct {
 	game.Game[*Player, *Board, AIComms]
+	Fallback FallbackAI // Plays for DQ'd players when substitutes are enabled, defaults to RandomAI
 }
 
 func New() *Game {
@@ -21,13 +22,34 @@ func New() *Game {
 }
 
 func (g *Game) Play() error {
+	return g.PlayContext(context.Background())
+}
+
+// PlayContext plays the game until it's over, or until ctx is done, in which case the game is aborted.
+func (g *Game) PlayContext(ctx context.Context) error {
+	return g.Finish(ctx, g.play(ctx, nil))
+}
+
+func (g *Game) play(ctx context.Context, s *game.Snapshot) (err error) {
 	// Wipe out any previous state
 	g.reset()
-	g.shufflePlayers()
+
+	passDirection := PassLeft
+	if s == nil {
+		g.seatPlayers()
+		_, passDirection = g.startPosition()
+	} else {
+		var err error
+		if passDirection, err = g.restore(s); err != nil {
+			return err
+		}
+	}
+	g.Start()
 
 	// We need to write down our setup
 	setupEvent := EventSetup{
-		Players: []EventSetupPlayer{},
+		Players:       []EventSetupPlayer{},
+		StartPosition: g.StartPosition(),
 	}
 
 	// Launch the player processes
@@ -35,12 +57,12 @@ func (g *Game) Play() error {
 		defer player.CleanUp()
 		defer g.SetOutput(player.ID, player)
 
-		err := player.Run()
+		err := player.Run(ctx)
 		if err != nil {
 			return fmt.Errorf("player %s failed to run, err: %s", player, err)
 		}
 
-		err = g.Comms.Setup(player, g.Players)
+		err = g.Comms.Setup(ctx, player, g.Players)
 		if err != nil {
 			return fmt.Errorf("player %s failed to setup, err: %s", player, err)
 		}
@@ -53,25 +75,61 @@ func (g *Game) Play() error {
 		setupEvent.Players = append(setupEvent.Players, esp)
 	}
 
-	g.EventLog.AddNone(setupEvent)
+	if s == nil {
+		g.AddNone(setupEvent)
+	}
 
-	passDirection := PassLeft
+	// Let everyone know how it went before they're shut down.
+	defer func() {
+		g.GameOver(ctx, err, func(ctx context.Context, p *Player, termination game.Termination) error {
+			return g.Comms.GameOver(ctx, p, termination, g.Places())
+		})
+	}()
+
+	// Everyone warms up before the first move, one at a time
+	for _, player := range g.Players {
+		if err := g.WarmUp(ctx, player); err != nil && !g.Substitute(player, err) {
+			g.setLoser(player)
+			return err
+		}
+	}
+
+	// A resumed game picks up partway through the round it was checkpointed in, so there's no deal.
+	resuming := s != nil
 	for !g.gameOver() {
-		g.dealCards()
+		if !resuming {
+			g.dealCards()
+			g.Board.PassDirection = passDirection
+		}
 
-		if passDirection != PassNone {
-			if dqPlayer, err := g.passCards(passDirection); err != nil {
+		if (!resuming && passDirection != PassNone) || g.Board.Passes != nil {
+			if dqPlayer, err := g.passCards(ctx, passDirection); err != nil {
 				g.setLoser(dqPlayer)
 				return err
 			}
 		}
+		resuming = false
 
-		if dqPlayer, err := g.playRound(); err != nil {
+		if dqPlayer, err := g.playRound(ctx); err != nil {
 			g.setLoser(dqPlayer)
 			return err
 		}
 
 		passDirection = passDirection.Next()
+
+		// Out of plies, so the scores so far decide it
+		if g.PlyCapReached() && !g.gameOver() {
+			g.SetPlaces(g.Board.Scores.Places())
+			e := game.EventAdjudication{
+				Reason: g.PlyCapReason(),
+				Basis:  "points (fewest wins)",
+			}
+			for _, p := range g.Players {
+				e.Scores = append(e.Scores, game.AdjudicationScore{ID: p.ID, Score: g.Board.Scores.Totals[p]})
+			}
+			g.Adjudicate(e)
+			return nil
+		}
 	}
 
 	// We're done, set the places for each player.
@@ -81,39 +139,16 @@ func (g *Game) Play() error {
 }
 
 func (g *Game) Events() []fmt.Stringer {
-	events := make([]fmt.Stringer, len(g.EventLog))
-
-	for i, event := range g.EventLog {
-		var eStr fmt.Stringer
-
-		switch event.Type {
-		case EventTypeSetup:
-			e := EventSetup{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeDeal:
-			e := EventDeal{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypePass:
-			e := EventPass{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypePlay:
-			e := EventPlay{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeScoreTrick:
-			e := EventScoreTrick{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeScoreRound:
-			e := EventScoreRound{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		}
-
-		events[i] = eStr
+	return g.EventsFor(game.ViewFull)
+}
+
+// EventsFor describes the events seen through v.
+func (g *Game) EventsFor(v game.View) []fmt.Stringer {
+	rawEvents := g.RawEventsFor(v)
+	events := make([]fmt.Stringer, len(rawEvents))
+
+	for i, event := range rawEvents {
+		events[i] = DecodeEvent(event)
 	}
 
 	return events
@@ -122,17 +157,38 @@ func (g *Game) Events() []fmt.Stringer {
 func (g *Game) reset() {
 	g.Game.Reset()
 	g.Board = NewBoard(g.Players)
+	g.Board.Deck = card.NewStandardDeckWith(g.Rand()) // So a given seed always deals the same cards
 	if g.Comms == nil {
 		g.Comms = NewComms(g)
 	}
 }
 
 func (g *Game) dealCards() {
-	g.Board.Deck.Shuffle()
-	for _, player := range g.Players {
+	// A custom position fixes the hands for the first round only.
+	startHands, _ := g.startPosition()
+	if len(g.Board.Scores.Rounds) > 0 {
+		startHands = nil
+	}
+
+	// Nobody has taken any points yet, not even in the state that comes with the pass.
+	g.Board.RoundScores = map[*Player]int{}
+	g.Board.Trick = []card.Card{}
+	g.Board.Leader = nil
+	g.Board.TrickCount = 0
+	g.Board.HeartsBroken = false
+	g.Board.TookPoints = map[*Player]bool{}
+
+	if startHands == nil {
+		g.Board.Deck.ShuffleWith(g.Rand())
+	}
+	for seat, player := range g.Players {
 		hand := Hand{}
-		for i := 0; i < 13; i++ {
-			hand.Add(g.Board.Deck.DealCard())
+		if startHands != nil {
+			hand = append(hand, startHands[seat]...)
+		} else {
+			for i := 0; i < 13; i++ {
+				hand.Add(g.Board.Deck.DealCard())
+			}
 		}
 
 		hand.Sort()
@@ -142,43 +198,33 @@ func (g *Game) dealCards() {
 			ID:   player.ID,
 			Hand: hand,
 		}
-		g.EventLog.Add(e, []game.PlayerID{player.ID})
+		g.Add(e, []game.PlayerID{player.ID})
 	}
 }
 
-func (g *Game) passCards(passDirection PassDirection) (*Player, error) {
-	// First collect all the passes...
-	passes := map[*Player]PassMove{}
+func (g *Game) passCards(ctx context.Context, passDirection PassDirection) (*Player, error) {
+	// First collect all the passes, skipping anyone who passed before we were checkpointed...
+	if g.Board.Passes == nil {
+		g.Board.Passes = map[*Player]PassMove{}
+	}
 	for _, player := range g.Players {
-		passMove, err := g.Comms.GetPassMove(player, passDirection)
-		if err != nil {
-			switch e := err.(type) {
-			case game.DQError:
-				return player, g.AddDQErrorID(&e, player.ID)
-			case *game.DQError:
-				return player, g.AddDQErrorID(e, player.ID)
-			}
-			return player, err
+		if _, ok := g.Board.Passes[player]; ok {
+			continue
 		}
 
-		err = g.isValidPass(g.Board.Hands[player], passMove)
+		g.checkpoint()
+		passMove, err := g.getPassMove(ctx, player, passDirection)
 		if err != nil {
-			// Make sure to log the bad pass before we bomb out
-			g.logPassMove(player, g.getPassRecipient(player, passDirection), passMove.Cards)
-
-			return player, game.DQError{
-				ID:   player.ID,
-				Type: game.DQTypeInvalidMove,
-				Msg:  err.Error(),
-			}
+			return player, err
 		}
 
-		passes[player] = passMove
+		g.Board.Passes[player] = passMove
 	}
 
 	// ... and now distribute the passes. We do this so that no player gets their passed cards before
 	// they choose which to pass.
-	for passer, passMove := range passes {
+	for _, passer := range g.Players {
+		passMove := g.Board.Passes[passer]
 		recipient := g.getPassRecipient(passer, passDirection)
 		for _, card := range passMove.Cards {
 			g.Board.Hands[passer].Remove(card) // Remove the card from the passer's hand,
@@ -187,17 +233,57 @@ func (g *Game) passCards(passDirection PassDirection) (*Player, error) {
 		g.Board.Hands[recipient].Sort()
 		g.logPassMove(passer, recipient, passMove.Cards)
 	}
+	g.Board.Passes = nil
 
 	return nil, nil
 }
 
+func (g *Game) getPassMove(ctx context.Context, player *Player, passDirection PassDirection) (PassMove, error) {
+	if g.IsSubstitute(player.ID) {
+		passMove := g.fallback().GetPass(*g.Board.Hands[player], passDirection)
+		if err := g.isValidPass(g.Board.Hands[player], passMove); err != nil {
+			return passMove, fmt.Errorf("fallback AI made an invalid pass: %s", err)
+		}
+		return passMove, nil
+	}
+
+	passMove, err := g.Comms.GetPassMove(ctx, player, passDirection, g.Board.Hands[player].Hash(), g.playerState(player, []card.Card{}, 0, false))
+	if err == nil {
+		if err = g.isValidPass(g.Board.Hands[player], passMove); err == nil {
+			return passMove, nil
+		}
+
+		err = &game.DQError{
+			ID:   player.ID,
+			Type: game.DQTypeInvalidMove,
+			Msg:  err.Error(),
+		}
+		if !g.Options().Substitute {
+			// Make sure to log the bad pass before we bomb out
+			g.logPassMove(player, g.getPassRecipient(player, passDirection), passMove.Cards)
+		}
+	}
+
+	switch e := err.(type) {
+	case game.DQError:
+		err = g.AddDQErrorID(&e, player.ID)
+	case *game.DQError:
+		err = g.AddDQErrorID(e, player.ID)
+	}
+
+	if g.Substitute(player, err) {
+		return g.getPassMove(ctx, player, passDirection)
+	}
+	return passMove, err
+}
+
 func (g *Game) logPassMove(fromPlayer, toPlayer *Player, cards []card.Card) {
 	e := EventPass{
 		FromID: fromPlayer.ID,
 		ToID:   toPlayer.ID,
 		Cards:  cards,
 	}
-	g.EventLog.Add(e, []game.PlayerID{fromPlayer.ID, toPlayer.ID})
+	g.Add(e, []game.PlayerID{fromPlayer.ID, toPlayer.ID})
 }
 
 func (g *Game) isValidPass(h *Hand, m PassMove) error {
@@ -212,13 +298,7 @@ func (g *Game) isValidPass(h *Hand, m PassMove) error {
 }
 
 func (g *Game) getPassRecipient(p *Player, passDirection PassDirection) *Player {
-	playerIndex := -1
-	for i, player := range g.Players {
-		if player == p {
-			playerIndex = i
-			break
-		}
-	}
+	playerIndex := g.seat(p)
 
 	addon := 0
 	switch passDirection {
@@ -233,46 +313,26 @@ func (g *Game) getPassRecipient(p *Player, passDirection PassDirection) *Player
 	return g.Players[(playerIndex+addon)%4]
 }
 
-func (g *Game) playRound() (*Player, error) {
+func (g *Game) playRound(ctx context.Context) (*Player, error) {
 	// To kick off the round, we need to know who has the two of clubs.
-	turn := -1
-
-	for i, player := range g.Players {
-		for _, c := range *g.Board.Hands[player] {
-			if c.Suit == card.Clubs && c.Rank == card.Two {
-				turn = i
+	if g.Board.Leader == nil {
+		for _, player := range g.Players {
+			if g.Board.Hands[player].Contains(card.Card{Rank: card.Two, Suit: card.Clubs}) {
+				g.Board.Leader = player
 				break
 			}
 		}
 	}
 
-	scores := map[*Player]int{}
-	score := 0
-	heartsBroken := false
-	tookPoints := map[*Player]bool{}
-	var err error
-	var dqPlayer *Player
-
-	for i := 0; i < 13; i++ {
-		turn, score, dqPlayer, err = g.playTrick(turn, i, heartsBroken)
-		if err != nil {
+	for g.Board.TrickCount < 13 {
+		if dqPlayer, err := g.playTrick(ctx); err != nil {
 			return dqPlayer, err
 		}
-
-		// We deduce whether a heart got played or not based on the score.
-		// The only scores where no hearts were played are 0, 13 (QS only), -10 (JD only).
-		// Any other score means a heart was in the mix.
-		if !heartsBroken && score != 0 && score != 13 && score != -10 {
-			heartsBroken = true
-		}
-
-		scores[g.Players[turn]] += score
-
-		if score != 0 && score != -10 {
-			tookPoints[g.Players[turn]] = true
-		}
 	}
 
+	scores := g.Board.RoundScores
+	tookPoints := g.Board.TookPoints
+
 	// Before this round's in the books, check for a moonshot, which would change everything.
 	if len(tookPoints) == 1 {
 		// Only one player taking points: that's a moonshot
@@ -306,11 +366,50 @@ func (g *Game) playRound() (*Player, error) {
 		RoundScores: eventScores,
 		TotalScores: totalScores,
 	}
-	g.EventLog.AddAll(e)
+	g.AddAll(e)
 
 	return nil, nil
 }
 
+// playerState is sent along with pass and play requests to players who asked for the full state.
+func (g *Game) playerState(p *Player, trick []card.Card, trickCount int, heartsBroken bool) *PlayerState {
+	if !p.FullState {
+		return nil
+	}
+
+	state := PlayerState{
+		ID:            p.ID,
+		Position:      p.Position,
+		Players:       g.Players,
+		Hand:          *g.Board.Hands[p],
+		PassDirection: g.Board.PassDirection,
+		Trick:         trick,
+		TrickCount:    trickCount,
+		HeartsBroken:  heartsBroken,
+		RoundScores:   g.getPlayersMap(),
+		TotalScores:   g.getPlayersMap(),
+	}
+	for player, score := range g.Board.RoundScores {
+		state.RoundScores[player.ID] = score
+	}
+	for player, score := range g.Board.Scores.Totals {
+		state.TotalScores[player.ID] = score
+	}
+
+	return &state
+}
+
+// othersThan returns every player but p, in seat order.
+func (g *Game) othersThan(p *Player) []*Player {
+	others := []*Player{}
+	for _, player := range g.Players {
+		if player != p {
+			others = append(others, player)
+		}
+	}
+	return others
+}
+
 func (g *Game) getPlayersMap() map[game.PlayerID]int {
 	m := map[game.PlayerID]int{}
 	for _, player := range g.Players {
@@ -319,56 +418,120 @@ func (g *Game) getPlayersMap() map[game.PlayerID]int {
 	return m
 }
 
-func (g *Game) playTrick(turn int, trickCount int, heartsBroken bool) (int, int, *Player, error) {
-	trick := []card.Card{}
-	plays := map[card.Card]game.PlayerID{}
-	turns := map[card.Card]int{}
+// playTrick plays the current trick from wherever it's got to, then scores it and sets up the next.
+func (g *Game) playTrick(ctx context.Context) (*Player, error) {
+	b := g.Board
+	lead := g.seat(b.Leader)
 
-	// Collect a play from each player
-	for i := 0; i < 4; i++ {
+	// Collect a play from each player still to play
+	for len(b.Trick) < 4 {
+		turn := (lead + len(b.Trick)) % 4
 		player := g.Players[turn]
-		move, err := g.Comms.GetPlayMove(player, trick)
+
+		g.checkpoint()
+		move, err := g.getPlayMove(ctx, player, b.Trick, b.TrickCount, b.HeartsBroken)
 		if err != nil {
-			switch e := err.(type) {
-			case game.DQError:
-				return -1, -1, player, g.AddDQErrorID(&e, player.ID)
-			case *game.DQError:
-				return -1, -1, player, g.AddDQErrorID(e, player.ID)
-			}
-			return -1, -1, player, err
+			return player, err
 		}
 
-		err = g.isValidPlay(*g.Board.Hands[player], move, trick, trickCount, heartsBroken)
-		if err != nil {
-			// Make sure to log the bad play before we bomb out
-			g.logPlayMove(player, move.Card)
+		b.Trick = append(b.Trick, move.Card)
+		b.Hands[player].Remove(move.Card)
 
-			return -1, -1, player, game.DQError{
-				ID:   player.ID,
-				Type: game.DQTypeInvalidMove,
-				Msg:  err.Error(),
+		g.logPlayMove(player, move.Card)
+		g.AddPly() // Only checked between rounds
+
+		// Everyone but the next to play can think while they do. Who leads the next trick isn't known
+		// until this one's scored, so there's no pondering after the last card.
+		if len(b.Trick) < 4 {
+			next := g.Players[util.Increment(turn, 0, 3)]
+			if p, err := g.Ponder(ctx, g.othersThan(next)); err != nil && !g.Substitute(p, err) {
+				return p, err
 			}
 		}
+	}
 
-		trick = append(trick, move.Card)
-		g.Board.Hands[player].Remove(move.Card)
-		plays[move.Card] = player.ID
-		turns[move.Card] = turn
-		turn = util.Increment(turn, 0, 3)
+	// Now see what the trick is worth and who gets it.
+	topCard, score := g.evaluateTrick(b.Trick)
+	winner := b.Leader
+	for i, c := range b.Trick {
+		if c == topCard {
+			winner = g.Players[(lead+i)%4]
+			break
+		}
+	}
 
-		g.logPlayMove(player, move.Card)
+	// We deduce whether a heart got played or not based on the score.
+	// The only scores where no hearts were played are 0, 13 (QS only), -10 (JD only).
+	// Any other score means a heart was in the mix.
+	if !b.HeartsBroken && score != 0 && score != 13 && score != -10 {
+		b.HeartsBroken = true
 	}
 
-	// Now see what the trick is worth and who gets it.
-	topCard, score := g.evaluateTrick(trick)
+	b.RoundScores[winner] += score
+	if score != 0 && score != -10 {
+		b.TookPoints[winner] = true
+	}
 
 	e := EventScoreTrick{
-		ID:    plays[topCard],
+		ID:    winner.ID,
 		Score: score,
 	}
-	g.EventLog.AddAll(e)
+	g.AddAll(e)
+
+	// The winner leads the next trick.
+	b.Leader = winner
+	b.Trick = []card.Card{}
+	b.TrickCount++
 
-	return turns[topCard], score, nil, nil
+	return nil, nil
+}
+
+func (g *Game) getPlayMove(ctx context.Context, player *Player, trick []card.Card, trickCount int, heartsBroken bool) (PlayMove, error) {
+	if g.IsSubstitute(player.ID) {
+		move := g.fallback().GetPlay(*g.Board.Hands[player], trick, trickCount, heartsBroken)
+		if err := g.isValidPlay(*g.Board.Hands[player], move, trick, trickCount, heartsBroken); err != nil {
+			return move, fmt.Errorf("fallback AI made an invalid play: %s", err)
+		}
+		return move, nil
+	}
+
+	var legalMoves []PlayMove
+	if player.LegalMoves {
+		// Never nil for those who asked, so having no legal moves can't be mistaken for not asking
+		legalMoves = []PlayMove{}
+		for _, c := range g.Board.Hands[player].PossiblePlays(trick, trickCount, heartsBroken) {
+			legalMoves = append(legalMoves, PlayMove{Card: c})
+		}
+	}
+
+	move, err := g.Comms.GetPlayMove(ctx, player, trick, g.Board.Hands[player].Hash(), legalMoves, g.playerState(player, trick, trickCount, heartsBroken))
+	if err == nil {
+		if err = g.isValidPlay(*g.Board.Hands[player], move, trick, trickCount, heartsBroken); err == nil {
+			return move, nil
+		}
+
+		err = &game.DQError{
+			ID:   player.ID,
+			Type: game.DQTypeInvalidMove,
+			Msg:  err.Error(),
+		}
+		if !g.Options().Substitute {
+			// Make sure to log the bad play before we bomb out
+			g.logPlayMove(player, move.Card)
+		}
+	}
+
+	switch e := err.(type) {
+	case game.DQError:
+		err = g.AddDQErrorID(&e, player.ID)
+	case *game.DQError:
+		err = g.AddDQErrorID(e, player.ID)
+	}
+
+	if g.Substitute(player, err) {
+		return g.getPlayMove(ctx, player, trick, trickCount, heartsBroken)
+	}
+	return move, err
 }
 
 func (g *Game) logPlayMove(player *Player, card card.Card) {
@@ -376,7 +539,7 @@ func (g *Game) logPlayMove(player *Player, card card.Card) {
 		ID:   player.ID,
 		Card: card,
 	}
-	g.EventLog.AddAll(e)
+	g.AddAll(e)
 }
 
 func (g *Game) isValidPlay(h Hand, m PlayMove, trick []card.Card, trickCount int, heartsBroken bool) error {
@@ -424,9 +587,30 @@ func (g *Game) evaluateTrick(trick []card.Card) (card.Card, int) {
 	return trick[winner], score
 }
 
-func (g *Game) shufflePlayers() {
-	util.Shuffle(g.Players)
+func (g *Game) fallback() FallbackAI {
+	if g.Fallback == nil {
+		return RandomAI{}
+	}
+	return g.Fallback
+}
+
+func (g *Game) seatPlayers() {
+	g.SeatPlayers()
+	g.assignSeats()
+}
+
+// seat returns p's index into Players, or -1 if they're not playing.
+func (g *Game) seat(p *Player) int {
+	for i, player := range g.Players {
+		if player == p {
+			return i
+		}
+	}
+	return -1
+}
 
+// assignSeats sets up each player according to their place at the table.
+func (g *Game) assignSeats() {
 	for i := 1; i <= 4; i++ {
 		g.Players[i-1].Position = i
 	}
@@ -443,21 +627,33 @@ func (g *Game) gameOver() bool {
 }
 
 func (g *Game) setLoser(p *Player) {
-	places := []game.Place{}
-
+	// The disqualified player takes last place, everyone else is ranked according to the DQ policy.
+	remaining := []*Player{}
 	for _, player := range g.Players {
-		rank := 1
-		if player == p {
-			// Last place
-			rank = 4
+		if player != p {
+			remaining = append(remaining, player)
 		}
+	}
 
-		places = append(places, game.Place{
-			Player: player.Player,
-			Rank:   rank,
-			Score:  g.Board.Scores.Totals[player],
-		})
+	places := []game.Place{}
+	if g.Options().DQRanking == game.DQRankShared {
+		for _, player := range remaining {
+			places = append(places, game.Place{
+				Player: player.Player,
+				Rank:   1,
+				Tie:    true,
+				Score:  g.Board.Scores.Totals[player],
+			})
+		}
+	} else {
+		places = g.Board.Scores.PlacesFor(remaining)
 	}
 
+	places = append(places, game.Place{
+		Player: p.Player,
+		Rank:   len(g.Players),
+		Score:  g.Board.Scores.Totals[p],
+	})
+
 	g.SetPlaces(places)
 }
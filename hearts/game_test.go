@@ -1,6 +1,8 @@
 package hearts
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -96,7 +98,7 @@ func TestPassCards(t *testing.T) {
 	for _, test := range tests {
 		g := getGame(test.hands)
 
-		_, err := g.passCards(test.passDirection)
+		_, err := g.passCards(context.Background(), test.passDirection)
 		if err != nil {
 			t.Errorf("Unexpected error: %s", err)
 		}
@@ -186,7 +188,7 @@ func TestPlayRound(t *testing.T) {
 	for _, test := range tests {
 		g := getGame(test.hands)
 
-		_, err := g.playRound()
+		_, err := g.playRound(context.Background())
 		if err != nil {
 			t.Errorf("Unexpected error: %s", err)
 		}
@@ -240,3 +242,316 @@ func TestEvaluateTrick(t *testing.T) {
 		}
 	}
 }
+
+func TestSetLoser(t *testing.T) {
+	tests := []struct {
+		ranking game.DQRanking
+		totals  []int
+		dq      int
+		ranks   []int // In player order
+		ties    []bool
+	}{
+		{game.DQRankStandings, []int{40, 10, 25, 3}, 4, []int{3, 1, 2, 4}, []bool{false, false, false, false}},
+		{game.DQRankStandings, []int{12, 12, 0, 30}, 3, []int{1, 1, 4, 3}, []bool{true, true, false, false}},
+		{game.DQRankStandings, []int{0, 0, 0, 0}, 1, []int{4, 1, 1, 1}, []bool{false, true, true, true}},
+		{game.DQRankShared, []int{40, 10, 25, 3}, 2, []int{1, 4, 1, 1}, []bool{true, false, true, true}},
+	}
+
+	for _, test := range tests {
+		g := getGame(map[int][]string{})
+		g.SetOptions(game.Options{DQRanking: test.ranking})
+		g.Board.Scores.AddRound(getRound(test.totals, g.Players))
+
+		g.setLoser(g.Players[test.dq-1])
+
+		for _, place := range g.Places() {
+			i := int(place.Player.ID) - 1
+			if place.Rank != test.ranks[i] || place.Tie != test.ties[i] {
+				t.Errorf("ranking: %s totals: %v dq: %d got place: %s expected rank: %d tie: %t",
+					test.ranking, test.totals, test.dq, place, test.ranks[i], test.ties[i])
+			}
+		}
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	hands := map[int][]string{
+		1: {"2C", "3C", "4C", "5C", "6C", "7C", "8C", "9C", "TC", "JC", "QC", "KC", "AC"},
+		2: {"2D", "3D", "4D", "5D", "6D", "7D", "8D", "9D", "TD", "JD", "QD", "KD", "AD"},
+		3: {"2S", "3S", "4S", "5S", "6S", "7S", "8S", "9S", "TS", "JS", "QS", "KS", "AS"},
+		4: {"2H", "3H", "4H", "5H", "6H", "7H", "8H", "9H", "TH", "JH", "QH", "KH", "AH"},
+	}
+
+	for _, substitute := range []bool{false, true} {
+		g := getGame(hands)
+		g.Comms.(*CommsMock).dq = map[int]bool{2: true}
+		g.SetOptions(game.Options{Substitute: substitute})
+
+		dqPlayer, err := g.passCards(context.Background(), PassLeft)
+		if !substitute {
+			if err == nil || dqPlayer != g.Players[1] {
+				t.Errorf("expected player 2 to be DQ'd, got player: %v err: %s", dqPlayer, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !g.IsSubstitute(g.Players[1].ID) {
+			t.Errorf("expected player 2 to be substituted")
+		}
+		for _, player := range g.Players {
+			if len(*g.Board.Hands[player]) != 13 {
+				t.Errorf("expected 13 cards for player %s, got: %s", player, g.Board.Hands[player])
+			}
+		}
+
+		found := false
+		for _, e := range g.EventLog {
+			if e.Type == game.EventTypeSubstitute {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no substitute event logged")
+		}
+
+		// Player 2 would have won, but they're DQ'd so they finish last
+		g.Board.Scores.AddRound(getRound([]int{10, 0, 4, 10}, g.Players))
+		g.SetPlaces(g.Board.Scores.Places())
+		expected := map[game.PlayerID]game.Place{
+			1: {Rank: 2, Tie: true},
+			2: {Rank: 4},
+			3: {Rank: 1},
+			4: {Rank: 2, Tie: true},
+		}
+		for _, place := range g.Places() {
+			if place.Rank != expected[place.Player.ID].Rank || place.Tie != expected[place.Player.ID].Tie {
+				t.Errorf("got place: %s expected: %+v", place, expected[place.Player.ID])
+			}
+		}
+	}
+}
+
+type checkpointObserver struct {
+	game.NopObserver
+	snapshots []*game.Snapshot
+}
+
+func (o *checkpointObserver) OnCheckpoint(s *game.Snapshot) {
+	o.snapshots = append(o.snapshots, s)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	g := getGame(map[int][]string{})
+	// Every player is DQ'd and taken over by the fallback AI, which lets us play a whole game.
+	g.Comms = &CommsMock{dq: map[int]bool{1: true, 2: true, 3: true, 4: true}}
+	g.SetOptions(game.Options{Substitute: true})
+	o := &checkpointObserver{}
+	g.AddObserver(o)
+
+	if err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(o.snapshots) < 2 {
+		t.Fatalf("expected a checkpoint per pass and play, got: %d", len(o.snapshots))
+	}
+
+	// Restoring a snapshot into a fresh game and checkpointing straight away should get us the same snapshot.
+	s := o.snapshots[len(o.snapshots)/2]
+	g2 := getGame(map[int][]string{})
+	g2.reset()
+	if _, err := g2.restore(s); err != nil {
+		t.Fatalf("unexpected error restoring: %s", err)
+	}
+	g2.checkpoint()
+	s2 := g2.LastCheckpoint()
+
+	if string(s2.State) != string(s.State) {
+		t.Errorf("restored state doesn't match, got: %s expected: %s", s2.State, s.State)
+	}
+	if string(s2.Rand) != string(s.Rand) {
+		t.Errorf("restored randomness doesn't match")
+	}
+	if !reflect.DeepEqual(s2.Substitutes, s.Substitutes) {
+		t.Errorf("restored substitutes don't match, got: %v expected: %v", s2.Substitutes, s.Substitutes)
+	}
+}
+
+// lowestAI always passes and plays its lowest cards, so a game it plays can be replayed exactly.
+type lowestAI struct{}
+
+func (ai lowestAI) GetPass(hand Hand, direction PassDirection) PassMove {
+	return PassMove{Cards: append([]card.Card{}, hand[:3]...)}
+}
+
+func (ai lowestAI) GetPlay(hand Hand, trick []card.Card, trickCount int, heartsBroken bool) PlayMove {
+	return PlayMove{Card: hand.PossiblePlays(trick, trickCount, heartsBroken)[0]}
+}
+
+func TestResumeMidTrick(t *testing.T) {
+	newGame := func() *Game {
+		g := getGame(map[int][]string{})
+		g.Comms = &CommsMock{dq: map[int]bool{1: true, 2: true, 3: true, 4: true}}
+		g.Fallback = lowestAI{}
+		g.SetOptions(game.Options{Substitute: true})
+		g.SetSeed(3)
+		return g
+	}
+
+	g := newGame()
+	o := &checkpointObserver{}
+	g.AddObserver(o)
+	if err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Find a checkpoint partway through a trick in the second round.
+	var s *game.Snapshot
+	for _, snapshot := range o.snapshots {
+		state := snapshotState{}
+		if err := json.Unmarshal(snapshot.State, &state); err != nil {
+			t.Fatalf("couldn't unmarshal state: %s", err)
+		}
+		if len(state.Rounds) == 1 && state.TrickCount > 0 && len(state.Trick) == 2 {
+			s = snapshot
+			break
+		}
+	}
+	if s == nil {
+		t.Fatalf("no mid-trick checkpoint in %d snapshots", len(o.snapshots))
+	}
+
+	g2 := newGame()
+	if err := g2.Resume(context.Background(), s); err != nil {
+		t.Fatalf("unexpected error resuming: %s", err)
+	}
+
+	if len(g2.EventLog) != len(g.EventLog) {
+		t.Errorf("resumed game has %d events, expected %d", len(g2.EventLog), len(g.EventLog))
+	}
+	for i, p := range g.Players {
+		if got, expected := g2.Board.Scores.Totals[g2.Players[i]], g.Board.Scores.Totals[p]; got != expected {
+			t.Errorf("resumed total for %s is %d, expected %d", p, got, expected)
+		}
+	}
+}
+
+func TestSeededDeals(t *testing.T) {
+	g := getGame(map[int][]string{})
+	g.SetSeed(7)
+
+	deal := func(seats []game.PlayerID) []Hand {
+		if err := g.SetSeats(seats); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		g.reset()
+		g.seatPlayers()
+		g.dealCards()
+
+		hands := []Hand{}
+		for _, p := range g.Players {
+			hands = append(hands, *g.Board.Hands[p])
+		}
+		return hands
+	}
+
+	// Each seat gets the same cards whoever is sitting in it
+	first := deal([]game.PlayerID{1, 2, 3, 4})
+	rotated := deal([]game.PlayerID{2, 3, 4, 1})
+	if g.Players[0].ID != 2 {
+		t.Errorf("Expected player 2 in the first seat, got: %d", g.Players[0].ID)
+	}
+	if !reflect.DeepEqual(first, rotated) {
+		t.Errorf("Same seed dealt different hands:\n%v\n%v", first, rotated)
+	}
+
+	g.SetSeed(8)
+	if reflect.DeepEqual(first, deal([]game.PlayerID{1, 2, 3, 4})) {
+		t.Errorf("Different seeds dealt the same hands")
+	}
+}
+
+func TestPlayerState(t *testing.T) {
+	g := getGame(map[int][]string{
+		1: {"2C", "QS"},
+		2: {"3C", "AH"},
+		3: {"4C", "5D"},
+		4: {"5C", "6S"},
+	})
+	g.Board.PassDirection = PassRight
+	g.Board.RoundScores[g.Players[1]] = 13
+	g.Board.Scores.AddRound(map[*Player]int{g.Players[0]: 4, g.Players[1]: 22})
+
+	p := g.Players[2]
+	trick := []card.Card{card.FromString("2C"), card.FromString("3C")}
+	if state := g.playerState(p, trick, 11, true); state != nil {
+		t.Errorf("Expected no state for a player who didn't opt in, got: %+v", state)
+	}
+
+	p.FullState = true
+	state := g.playerState(p, trick, 11, true)
+	if state == nil {
+		t.Fatalf("Expected state for a player who opted in")
+	}
+
+	if state.ID != p.ID || state.Position != p.Position || state.PassDirection != PassRight || state.TrickCount != 11 || !state.HeartsBroken {
+		t.Errorf("Expected state for P%d passing right in trick 11 with hearts broken, got: %+v", p.ID, state)
+	}
+	if !reflect.DeepEqual(state.Hand, *getHand([]string{"4C", "5D"})) {
+		t.Errorf("Expected own hand [4C 5D], got: %s", state.Hand)
+	}
+	if !reflect.DeepEqual(state.Trick, trick) {
+		t.Errorf("Expected trick %s, got: %s", trick, state.Trick)
+	}
+
+	expectedRound := map[game.PlayerID]int{1: 0, 2: 13, 3: 0, 4: 0}
+	if !reflect.DeepEqual(state.RoundScores, expectedRound) {
+		t.Errorf("Expected round scores %v, got: %v", expectedRound, state.RoundScores)
+	}
+	expectedTotals := map[game.PlayerID]int{1: 4, 2: 22, 3: 0, 4: 0}
+	if !reflect.DeepEqual(state.TotalScores, expectedTotals) {
+		t.Errorf("Expected total scores %v, got: %v", expectedTotals, state.TotalScores)
+	}
+}
+
+func TestPassStateNewRound(t *testing.T) {
+	// Player 2 shoots the moon in the first round
+	g := getGame(map[int][]string{
+		1: {"4C", "7C", "TC", "4D", "7D", "TD", "4S", "7S", "TS", "4H", "7H", "TH", "JS"},
+		2: {"AC", "KC", "QC", "AD", "KD", "QD", "AS", "KS", "QS", "AH", "KH", "QH", "JH"},
+		3: {"2C", "5C", "8C", "2D", "5D", "8D", "2S", "5S", "8S", "2H", "5H", "8H", "JC"},
+		4: {"3C", "6C", "9C", "3D", "6D", "9D", "3S", "6S", "9S", "3H", "6H", "9H", "JD"},
+	})
+	if _, err := g.playRound(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	g.dealCards()
+	comms := g.Comms.(*CommsMock)
+	for _, p := range g.Players {
+		p.FullState = true
+		comms.hands[p.Position] = []string{}
+		for _, c := range *g.Board.Hands[p] {
+			comms.hands[p.Position] = append(comms.hands[p.Position], c.String())
+		}
+	}
+	if _, err := g.passCards(context.Background(), PassRight); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedRound := map[game.PlayerID]int{1: 0, 2: 0, 3: 0, 4: 0}
+	expectedTotals := map[game.PlayerID]int{1: 26, 2: -10, 3: 26, 4: 26}
+	if len(comms.passStates) != 4 {
+		t.Fatalf("Expected a state with each of 4 passes, got %d", len(comms.passStates))
+	}
+	for _, state := range comms.passStates {
+		if !reflect.DeepEqual(state.RoundScores, expectedRound) {
+			t.Errorf("Expected round scores %v for P%d, got: %v", expectedRound, state.ID, state.RoundScores)
+		}
+		if !reflect.DeepEqual(state.TotalScores, expectedTotals) {
+			t.Errorf("Expected total scores %v for P%d, got: %v", expectedTotals, state.ID, state.TotalScores)
+		}
+	}
+}
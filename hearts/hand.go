@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/game/elements/card"
 )
 
@@ -104,3 +105,10 @@ func (h *Hand) PossiblePlays(trick []card.Card, trickCount int, heartsBroken boo
 
 	return plays
 }
+
+// Hash fingerprints the hand regardless of the order it's in, so a driver can check its copy against ours.
+func (h Hand) Hash() string {
+	sorted := append(Hand{}, h...)
+	sorted.Sort()
+	return game.Hash(sorted)
+}
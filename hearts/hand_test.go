@@ -201,3 +201,19 @@ func getCards(cards []string) []card.Card {
 	}
 	return hand
 }
+
+func TestHandHash(t *testing.T) {
+	h1 := *getHand([]string{"2C", "QS", "AH"})
+	h2 := *getHand([]string{"AH", "2C", "QS"})
+	h3 := *getHand([]string{"2C", "QS", "KH"})
+
+	if h1.Hash() != h2.Hash() {
+		t.Errorf("Expected the same cards in a different order to hash the same")
+	}
+	if h1.Hash() == h3.Hash() {
+		t.Errorf("Expected different cards to hash differently")
+	}
+	if !reflect.DeepEqual(h2, *getHand([]string{"AH", "2C", "QS"})) {
+		t.Errorf("Hashing changed the order of the hand: %s", h2)
+	}
+}
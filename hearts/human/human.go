@@ -0,0 +1,105 @@
+package human
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+	"github.com/boardgamesai/games/hearts"
+	"github.com/boardgamesai/games/hearts/ai/driver"
+)
+
+// Human lets a person play Hearts from the terminal, typing cards like "QS" or "TH".
+type Human struct {
+	t *game.Terminal
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetPass(state driver.State) hearts.PassMove {
+	h.t.ShowEvents(state.NewEvents, hearts.DecodeEvent)
+	h.t.Printf("\nYou're ID %d. Your hand: %s\n", state.ID, cards(sorted(state.Hand)))
+
+	move := hearts.PassMove{}
+	h.t.Ask(fmt.Sprintf("Cards to pass %s (e.g. QS AH 2C): ", state.PassDirection), func(answer string) error {
+		passed, err := parseCards(answer)
+		if err != nil {
+			return err
+		}
+		if err := state.Hand.IsValidPass(passed); err != nil {
+			return fmt.Errorf("can't pass those: %s", err)
+		}
+
+		move.Cards = passed
+		return nil
+	})
+
+	return move
+}
+
+func (h *Human) GetPlay(state driver.State) hearts.PlayMove {
+	h.t.ShowEvents(state.NewEvents, hearts.DecodeEvent)
+	plays := state.Hand.PossiblePlays(state.Trick, state.TrickCount, state.HeartsBroken)
+	h.t.Printf("\nYou're ID %d. Trick so far: %s\nYour hand: %s\nLegal plays: %s\n", state.ID, cards(state.Trick), cards(sorted(state.Hand)), cards(sorted(plays)))
+
+	move := hearts.PlayMove{}
+	h.t.Ask("Your play (e.g. QS): ", func(answer string) error {
+		c, err := card.Parse(strings.ToUpper(answer))
+		if err != nil {
+			return err
+		}
+
+		for _, play := range plays {
+			if play == c {
+				move.Card = c
+				return nil
+			}
+		}
+		return fmt.Errorf("can't play %s now", c)
+	})
+
+	return move
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, hearts.DecodeEvent)
+}
+
+func parseCards(s string) ([]card.Card, error) {
+	parsed := []card.Card{}
+	for _, field := range strings.Fields(strings.ToUpper(s)) {
+		c, err := card.Parse(field)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed, nil
+}
+
+// sorted copies the cards before sorting, so the driver's hand isn't reordered under it.
+func sorted(cs []card.Card) hearts.Hand {
+	h := append(hearts.Hand{}, cs...)
+	h.Sort()
+	return h
+}
+
+func cards(cs []card.Card) string {
+	if len(cs) == 0 {
+		return "-"
+	}
+
+	strs := []string{}
+	for _, c := range cs {
+		strs = append(strs, c.String())
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,124 @@
+package hearts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
+
+// Knowledge is what each player may know in Hearts: their own deal, the cards passed to and by them,
+// and everything played and scored.
+type Knowledge struct{}
+
+func (g *Game) Knowledge() game.Knowledge {
+	return Knowledge{}
+}
+
+func (k Knowledge) MayKnow(id game.PlayerID, e game.Event) bool {
+	switch e.Type {
+	case EventTypeDeal:
+		deal := EventDeal{}
+		json.Unmarshal(e.Data, &deal)
+		return deal.ID == id
+	case EventTypePass:
+		pass := EventPass{}
+		json.Unmarshal(e.Data, &pass)
+		return pass.FromID == id || pass.ToID == id
+	}
+
+	return true
+}
+
+// CheckMessage makes sure the hand a player is told about, in the hash, the legal moves or the state, is
+// the one the events dealt and passed them, and that every card in a trick has been played already.
+func (k Knowledge) CheckMessage(id game.PlayerID, m game.Message, known []game.Event) []game.Leak {
+	if m.Type != "pass" && m.Type != "play" {
+		return nil
+	}
+
+	// Passes and plays share everything we check, a pass just has no trick or legal moves.
+	message := MessagePlay{}
+	if err := json.Unmarshal(m.Data, &message); err != nil {
+		return []game.Leak{{Msg: err.Error()}}
+	}
+
+	// The hand is whatever was dealt, passed and not yet played this round, and the trick so far is
+	// whatever's been played since the last one was scored.
+	hand := Hand{}
+	played := map[card.Card]bool{}
+	for _, e := range known {
+		switch e.Type {
+		case EventTypeDeal:
+			deal := EventDeal{}
+			json.Unmarshal(e.Data, &deal)
+			if deal.ID == id {
+				hand = append(Hand{}, deal.Hand...)
+			}
+		case EventTypePass:
+			pass := EventPass{}
+			json.Unmarshal(e.Data, &pass)
+			for _, c := range pass.Cards {
+				if pass.FromID == id {
+					hand.Remove(c)
+				}
+				if pass.ToID == id {
+					hand.Add(c)
+				}
+			}
+		case EventTypePlay:
+			play := EventPlay{}
+			json.Unmarshal(e.Data, &play)
+			played[play.Card] = true
+			if play.ID == id {
+				hand.Remove(play.Card)
+			}
+		case EventTypeScoreTrick:
+			played = map[card.Card]bool{}
+		}
+	}
+
+	leaks := trickLeaks("Trick", message.Trick, played)
+	if message.Hash != "" && message.Hash != hand.Hash() {
+		leaks = append(leaks, game.Leak{
+			Field: "Hash",
+			Msg:   fmt.Sprintf("doesn't match the hand %s", hand),
+		})
+	}
+	for _, move := range message.LegalMoves {
+		if !hand.Contains(move.Card) {
+			leaks = append(leaks, game.Leak{
+				Field: "LegalMoves",
+				Msg:   fmt.Sprintf("%s isn't in the hand", move.Card),
+			})
+		}
+	}
+
+	if message.State != nil {
+		for _, c := range message.State.Hand {
+			if !hand.Contains(c) {
+				leaks = append(leaks, game.Leak{
+					Field: "State.Hand",
+					Msg:   fmt.Sprintf("%s isn't in the hand", c),
+				})
+			}
+		}
+		leaks = append(leaks, trickLeaks("State.Trick", message.State.Trick, played)...)
+	}
+	return leaks
+}
+
+// trickLeaks returns a Leak for every card in the trick that hasn't been played.
+func trickLeaks(field string, trick []card.Card, played map[card.Card]bool) []game.Leak {
+	leaks := []game.Leak{}
+	for _, c := range trick {
+		if !played[c] {
+			leaks = append(leaks, game.Leak{
+				Field: field,
+				Msg:   fmt.Sprintf("%s hasn't been played", c),
+			})
+		}
+	}
+	return leaks
+}
@@ -0,0 +1,113 @@
+package hearts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
+
+func getKnowledgeEvent(t *testing.T, eventType string, data interface{}) game.Event {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Encoding event returned error: %s", err)
+	}
+	return game.Event{Type: eventType, Data: dataJSON}
+}
+
+func TestKnowledgeMayKnow(t *testing.T) {
+	k := Knowledge{}
+	deal := getKnowledgeEvent(t, EventTypeDeal, EventDeal{ID: 1, Hand: *getHand([]string{"2C"})})
+	pass := getKnowledgeEvent(t, EventTypePass, EventPass{FromID: 1, ToID: 2, Cards: []card.Card{card.FromString("2C")}})
+	play := getKnowledgeEvent(t, EventTypePlay, EventPlay{ID: 3, Card: card.FromString("2C")})
+
+	tests := []struct {
+		e        game.Event
+		expected map[game.PlayerID]bool
+	}{
+		{deal, map[game.PlayerID]bool{1: true, 2: false, 3: false, 4: false}},
+		{pass, map[game.PlayerID]bool{1: true, 2: true, 3: false, 4: false}},
+		{play, map[game.PlayerID]bool{1: true, 2: true, 3: true, 4: true}},
+	}
+
+	for _, test := range tests {
+		for id, expected := range test.expected {
+			if k.MayKnow(id, test.e) != expected {
+				t.Errorf("Expected P%d may know %s to be %t", id, test.e, expected)
+			}
+		}
+	}
+}
+
+func TestKnowledgeCheckTrick(t *testing.T) {
+	k := Knowledge{}
+	known := []game.Event{
+		getKnowledgeEvent(t, EventTypePlay, EventPlay{ID: 1, Card: card.FromString("2C")}),
+		getKnowledgeEvent(t, EventTypeScoreTrick, EventScoreTrick{ID: 1}),
+		getKnowledgeEvent(t, EventTypePlay, EventPlay{ID: 1, Card: card.FromString("3C")}),
+	}
+
+	tests := []struct {
+		trick  []string
+		leaked int
+	}{
+		{[]string{}, 0},
+		{[]string{"3C"}, 0},
+		{[]string{"3C", "4C"}, 1}, // 4C hasn't been played yet
+		{[]string{"2C", "3C"}, 1}, // 2C was in the last trick
+	}
+
+	for _, test := range tests {
+		trick := []card.Card{}
+		for _, c := range test.trick {
+			trick = append(trick, card.FromString(c))
+		}
+		data, _ := json.Marshal(MessagePlay{Trick: trick})
+
+		leaks := k.CheckMessage(2, game.Message{Type: "play", Data: data}, known)
+		if len(leaks) != test.leaked {
+			t.Errorf("Trick %v: expected %d leaks, got: %+v", test.trick, test.leaked, leaks)
+		}
+	}
+}
+
+func TestKnowledgeCheckState(t *testing.T) {
+	k := Knowledge{}
+	known := []game.Event{
+		getKnowledgeEvent(t, EventTypeDeal, EventDeal{ID: 2, Hand: *getHand([]string{"2C", "QS", "AH"})}),
+		getKnowledgeEvent(t, EventTypePass, EventPass{FromID: 2, ToID: 3, Cards: []card.Card{card.FromString("QS")}}),
+		getKnowledgeEvent(t, EventTypePass, EventPass{FromID: 1, ToID: 2, Cards: []card.Card{card.FromString("KD")}}),
+		getKnowledgeEvent(t, EventTypePlay, EventPlay{ID: 2, Card: card.FromString("2C")}),
+	}
+
+	tests := []struct {
+		hand       []string
+		hashOf     []string
+		legalMoves []string
+		leaked     int
+	}{
+		{[]string{"AH", "KD"}, []string{"KD", "AH"}, []string{"KD"}, 0},
+		{[]string{"AH", "KD", "QS"}, []string{"AH", "KD"}, []string{"KD"}, 1}, // QS was passed on
+		{[]string{"AH", "KD", "3S"}, []string{"AH", "KD"}, []string{"3S"}, 2}, // 3S is someone else's
+		{[]string{"AH", "KD"}, []string{"AH", "KD", "3S"}, []string{"AH"}, 1}, // So is the hand hashed
+	}
+
+	for _, test := range tests {
+		legalMoves := []PlayMove{}
+		for _, c := range test.legalMoves {
+			legalMoves = append(legalMoves, PlayMove{Card: card.FromString(c)})
+		}
+		data, _ := json.Marshal(MessagePlay{
+			Trick:      []card.Card{card.FromString("2C")},
+			Hash:       getHand(test.hashOf).Hash(),
+			LegalMoves: legalMoves,
+			State:      &PlayerState{Hand: *getHand(test.hand)},
+		})
+
+		leaks := k.CheckMessage(2, game.Message{Type: "play", Data: data}, known)
+		if len(leaks) != test.leaked {
+			t.Errorf("Hand %v: expected %d leaks, got: %+v", test.hand, test.leaked, leaks)
+		}
+	}
+}
@@ -14,9 +14,29 @@ type MessageSetup struct {
 type MessagePass struct {
 	Direction PassDirection
 	NewEvents []game.Event
+	Hash      string       // Of the player's hand once NewEvents are applied, so the driver can check it's in sync
+	State     *PlayerState `json:",omitempty"` // Only for players who opted in with FullState
 }
 
 type MessagePlay struct {
-	Trick     []card.Card
-	NewEvents []game.Event
+	Trick      []card.Card
+	NewEvents  []game.Event
+	Hash       string       // Of the player's hand once NewEvents are applied
+	LegalMoves []PlayMove   // Only for players who opted in with LegalMoves, null for everyone else
+	State      *PlayerState `json:",omitempty"` // Only for players who opted in with FullState
+}
+
+// PlayerState is everything the player can see of the game when asked to pass or play, so a client
+// doesn't have to keep track of it from the events. Scores are keyed by player ID.
+type PlayerState struct {
+	ID            game.PlayerID
+	Position      int
+	Players       []*Player
+	Hand          Hand
+	PassDirection PassDirection
+	Trick         []card.Card // Played so far, starting with the lead; empty when passing
+	TrickCount    int         // How many tricks have been played this round
+	HeartsBroken  bool
+	RoundScores   map[game.PlayerID]int // Points taken so far this round
+	TotalScores   map[game.PlayerID]int // Points from the rounds before this one
 }
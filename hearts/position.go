@@ -0,0 +1,82 @@
+package hearts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
+
+// SetPosition has the first round start from a fixed deal rather than a shuffled one, or goes back
+// to shuffling if position is "". It's each seat's 13 cards in order, separated by "/", optionally
+// followed by the first round's pass direction (left, across, right or none), e.g.
+// "2C3C...AS/4D5D...KH/.../... none". Rounds after the first are dealt as usual.
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the hands for the first round in seat order, or nil if they're to be
+// dealt as usual, along with the first round's pass direction.
+func (g *Game) startPosition() ([]Hand, PassDirection) {
+	if g.StartPosition() == "" {
+		return nil, PassLeft
+	}
+
+	// We've already validated this in SetPosition
+	hands, passDirection, _ := parsePosition(g.StartPosition())
+	return hands, passDirection
+}
+
+func parsePosition(position string) ([]Hand, PassDirection, error) {
+	fields := strings.Fields(position)
+	if len(fields) < 1 || len(fields) > 2 {
+		return nil, "", game.PositionError(position, "expected hands, optionally followed by a pass direction")
+	}
+
+	passDirection := PassLeft
+	if len(fields) == 2 {
+		passDirection = PassDirection(fields[1])
+		if passDirection != PassLeft && passDirection != PassAcross && passDirection != PassRight && passDirection != PassNone {
+			return nil, "", game.PositionError(position, fmt.Sprintf("unknown pass direction %q", fields[1]))
+		}
+	}
+
+	handStrs := strings.Split(fields[0], "/")
+	if len(handStrs) != 4 {
+		return nil, "", game.PositionError(position, fmt.Sprintf("expected 4 hands, got %d", len(handStrs)))
+	}
+
+	hands := []Hand{}
+	seen := map[card.Card]bool{}
+	for i, handStr := range handStrs {
+		if len(handStr) != 26 {
+			return nil, "", game.PositionError(position, fmt.Sprintf("expected 13 cards in hand %d", i+1))
+		}
+
+		hand := Hand{}
+		for j := 0; j < len(handStr); j += 2 {
+			c, err := card.Parse(handStr[j : j+2])
+			if err != nil {
+				return nil, "", game.PositionError(position, err.Error())
+			}
+			if seen[c] {
+				return nil, "", game.PositionError(position, fmt.Sprintf("%s is dealt twice", c))
+			}
+			seen[c] = true
+			hand.Add(c)
+		}
+
+		hand.Sort()
+		hands = append(hands, hand)
+	}
+
+	return hands, passDirection, nil
+}
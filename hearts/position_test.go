@@ -0,0 +1,40 @@
+package hearts
+
+import (
+	"testing"
+)
+
+const dealtPosition = "2C3C4C5C6C7C8C9CTCJCQCKCAC/2D3D4D5D6D7D8D9DTDJDQDKDAD/2H3H4H5H6H7H8H9HTHJHQHKHAH/2S3S4S5S6S7S8S9STSJSQSKSAS"
+
+func TestParsePosition(t *testing.T) {
+	hands, passDirection, err := parsePosition(dealtPosition + " across")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(hands) != 4 || passDirection != PassAcross {
+		t.Errorf("Got %d hands passing %s", len(hands), passDirection)
+	}
+	for _, hand := range hands {
+		if len(hand) != 13 {
+			t.Errorf("Expected 13 cards, got: %s", hand)
+		}
+	}
+
+	if _, passDirection, _ = parsePosition(dealtPosition); passDirection != PassLeft {
+		t.Errorf("Expected to pass left by default, got: %s", passDirection)
+	}
+
+	invalid := []string{
+		"",
+		dealtPosition + " sideways",
+		"2C3C4C5C6C7C8C9CTCJCQCKCAC/2D3D4D5D6D7D8D9DTDJDQDKDAD/2H3H4H5H6H7H8H9HTHJHQHKHAH",                            // 3 hands
+		"2C3C4C5C6C7C8C9CTCJCQCKCAC/2D3D4D5D6D7D8D9DTDJDQDKDAD/2H3H4H5H6H7H8H9HTHJHQHKHAH/2S3S4S",                     // Short hand
+		"2C3C4C5C6C7C8C9CTCJCQCKCAC/2D3D4D5D6D7D8D9DTDJDQDKDAD/2H3H4H5H6H7H8H9HTHJHQHKHAH/2C3S4S5S6S7S8S9STSJSQSKSAS", // 2C twice
+		"2C3C4C5C6C7C8C9CTCJCQCKCAC/2D3D4D5D6D7D8D9DTDJDQDKDAD/2H3H4H5H6H7H8H9HTHJHQHKHAH/1S3S4S5S6S7S8S9STSJSQSKSAS", // Bad rank
+	}
+	for _, position := range invalid {
+		if _, _, err := parsePosition(position); err == nil {
+			t.Errorf("%q: expected an error", position)
+		}
+	}
+}
@@ -28,6 +28,12 @@ func (s *Scores) AddRound(round map[*Player]int) {
 }
 
 func (s *Scores) Places() []game.Place {
+	return s.PlacesFor(s.Players())
+}
+
+// PlacesFor ranks just the given players by their totals, which is needed when one of them has been
+// disqualified, or when no round has been scored yet.
+func (s *Scores) PlacesFor(players []*Player) []game.Place {
 	type Pair struct {
 		score  int
 		player *Player
@@ -35,7 +41,6 @@ func (s *Scores) Places() []game.Place {
 	pairs := []Pair{}
 
 	ties := map[int]int{}
-	players := s.Players()
 
 	for _, player := range players {
 		score := s.Totals[player]
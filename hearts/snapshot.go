@@ -0,0 +1,111 @@
+package hearts
+
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/card"
+)
+
+// snapshotState is what we need on top of the game package's snapshot to pick up where we left off.
+// We checkpoint before every pass and play, so it's the whole round so far. Anything per player is
+// in the same order as Players.
+type snapshotState struct {
+	Deck          []card.Card
+	Rounds        [][]int // Scores for each finished round
+	PassDirection PassDirection
+	Hands         []Hand
+	Passes        [][]card.Card `json:",omitempty"` // Only while passing, empty for anyone yet to pass
+	Trick         []card.Card
+	Leader        int // -1 until the round's first lead
+	TrickCount    int
+	HeartsBroken  bool
+	RoundScores   []int
+	TookPoints    []bool
+}
+
+// Resume picks up a game from a snapshot, such as one from LastCheckpoint, at the pass or play it
+// was taken before. The players are relaunched and sent the whole event history with their next
+// move request.
+func (g *Game) Resume(ctx context.Context, s *game.Snapshot) error {
+	return g.Finish(ctx, g.play(ctx, s))
+}
+
+func (g *Game) checkpoint() {
+	b := g.Board
+	state := snapshotState{
+		Deck:          b.Deck.Cards(),
+		Rounds:        [][]int{},
+		PassDirection: b.PassDirection,
+		Hands:         []Hand{},
+		Trick:         append([]card.Card{}, b.Trick...),
+		Leader:        g.seat(b.Leader),
+		TrickCount:    b.TrickCount,
+		HeartsBroken:  b.HeartsBroken,
+		RoundScores:   []int{},
+		TookPoints:    []bool{},
+	}
+
+	for _, round := range b.Scores.Rounds {
+		scores := []int{}
+		for _, p := range g.Players {
+			scores = append(scores, round[p])
+		}
+		state.Rounds = append(state.Rounds, scores)
+	}
+
+	for _, p := range g.Players {
+		state.Hands = append(state.Hands, append(Hand{}, *b.Hands[p]...))
+		state.RoundScores = append(state.RoundScores, b.RoundScores[p])
+		state.TookPoints = append(state.TookPoints, b.TookPoints[p])
+		if b.Passes != nil {
+			state.Passes = append(state.Passes, append([]card.Card{}, b.Passes[p].Cards...))
+		}
+	}
+
+	g.Checkpoint(state)
+}
+
+func (g *Game) restore(s *game.Snapshot) (PassDirection, error) {
+	state := snapshotState{}
+	if err := g.Restore(s, &state); err != nil {
+		return PassLeft, err
+	}
+
+	g.assignSeats()
+
+	b := g.Board
+	b.Deck.SetCards(state.Deck)
+	for _, scores := range state.Rounds {
+		round := map[*Player]int{}
+		for i, p := range g.Players {
+			round[p] = scores[i]
+		}
+		b.Scores.AddRound(round)
+	}
+
+	b.PassDirection = state.PassDirection
+	b.Trick = append([]card.Card{}, state.Trick...)
+	if state.Leader >= 0 {
+		b.Leader = g.Players[state.Leader]
+	}
+	b.TrickCount = state.TrickCount
+	b.HeartsBroken = state.HeartsBroken
+	if len(state.Passes) > 0 {
+		b.Passes = map[*Player]PassMove{}
+	}
+
+	for i, p := range g.Players {
+		hand := append(Hand{}, state.Hands[i]...)
+		b.Hands[p] = &hand
+		b.RoundScores[p] = state.RoundScores[i]
+		if state.TookPoints[i] {
+			b.TookPoints[p] = true
+		}
+		if len(state.Passes) > 0 && len(state.Passes[i]) > 0 {
+			b.Passes[p] = PassMove{Cards: state.Passes[i]}
+		}
+	}
+
+	return state.PassDirection, nil
+}
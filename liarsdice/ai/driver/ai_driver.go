@@ -1,6 +1,7 @@
 package driver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -39,15 +40,31 @@ func (d *Driver) Run() {
 			response, err = d.handleSetup(message.Data)
 		case "move":
 			response, err = d.handleMove(message.Data)
+		case "warmup":
+			response, err = d.handleWarmUp(message.Data)
+		case "ponder":
+			response, err = d.handlePonder(message.Data)
+		case "gameover":
+			response, err = d.handleGameOver(message.Data)
 		default:
 			log.Fatalf("Unknown message type: %s", message.Type)
 		}
 
+		if dqErr, ok := err.(*game.DQError); ok {
+			// Let the engine know why rather than crashing, e.g. on a desync
+			d.PrintErrorResponse(dqErr)
+			continue
+		}
 		if err != nil {
 			log.Fatalf("Error handling message: %+v err: %s", message, err)
 		}
 
 		d.PrintResponse(response)
+
+		// Nothing comes after the game over message, and we're about to be shut down
+		if message.Type == "gameover" {
+			return
+		}
 	}
 }
 
@@ -70,14 +87,84 @@ func (d *Driver) handleMove(message []byte) ([]byte, error) {
 		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
 	}
 
+	if err := d.processNewEvents(moveMessage.NewEvents); err != nil {
+		return []byte{}, err
+	}
+	hash := liarsdice.Hash(d.state.Dice, d.state.Bid, d.state.Quantity, d.state.DiceCounts)
+	if dqErr := d.CheckHash(d.state.ID, moveMessage.Hash, hash); dqErr != nil {
+		return []byte{}, dqErr
+	}
+
+	move := d.ai.GetMove(*(d.state))
+	if ai, ok := d.ai.(annotatingAI); ok {
+		d.Annotate(ai.Annotation())
+	}
+	moveJSON, err := json.Marshal(&move)
+	if err != nil {
+		return []byte{}, fmt.Errorf("JSON encode failed: %+v err: %s", move, err)
+	}
+	return moveJSON, nil
+}
+
+func (d *Driver) handleWarmUp(message []byte) ([]byte, error) {
+	warmUpMessage := game.MessageWarmUp{}
+	if err := json.Unmarshal(message, &warmUpMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if ai, ok := d.ai.(warmingUpAI); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), warmUpMessage.AITimeLimit())
+		defer cancel()
+		ai.WarmUp(ctx, *d.state)
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *Driver) handlePonder(message []byte) ([]byte, error) {
+	ponderMessage := game.MessagePonder{}
+	if err := json.Unmarshal(message, &ponderMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if err := d.processNewEvents(ponderMessage.NewEvents); err != nil {
+		return []byte{}, err
+	}
+
+	if ai, ok := d.ai.(ponderingAI); ok {
+		state := *d.state
+		d.StartPondering(func(ctx context.Context) {
+			ai.Ponder(ctx, state)
+		})
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *Driver) handleGameOver(message []byte) ([]byte, error) {
+	gameOverMessage := game.MessageGameOver{}
+	err := json.Unmarshal(message, &gameOverMessage)
+	if err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if err := d.processNewEvents(gameOverMessage.NewEvents); err != nil {
+		return []byte{}, err
+	}
+
+	if ai, ok := d.ai.(gameOverAI); ok {
+		ai.GameOver(*d.state, gameOverMessage)
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *Driver) processNewEvents(events []game.Event) error {
 	// Update our state based on all the new events
-	for _, e := range moveMessage.NewEvents {
+	for _, e := range events {
 		switch e.Type {
 		case liarsdice.EventTypeRoll:
 			// We will only get a roll if it's ours, no need to check IDs
 			eventRoll := liarsdice.EventRoll{}
 			if err := json.Unmarshal(e.Data, &eventRoll); err != nil {
-				return []byte{}, err
+				return err
 			}
 
 			d.state.Dice = eventRoll.Dice
@@ -86,7 +173,7 @@ func (d *Driver) handleMove(message []byte) ([]byte, error) {
 			// This is a non-challege move, challenges get their own event
 			eventMove := liarsdice.EventMove{}
 			if err := json.Unmarshal(e.Data, &eventMove); err != nil {
-				return []byte{}, err
+				return err
 			}
 
 			d.state.Bid = eventMove.Bid
@@ -100,7 +187,7 @@ func (d *Driver) handleMove(message []byte) ([]byte, error) {
 		case liarsdice.EventTypeChallenge:
 			eventChallenge := liarsdice.EventChallenge{}
 			if err := json.Unmarshal(e.Data, &eventChallenge); err != nil {
-				return []byte{}, err
+				return err
 			}
 
 			for ID, change := range eventChallenge.DiceChange {
@@ -116,14 +203,9 @@ func (d *Driver) handleMove(message []byte) ([]byte, error) {
 			}
 		}
 	}
-	d.state.AddEvents(moveMessage.NewEvents)
+	d.state.AddEvents(events)
 
-	move := d.ai.GetMove(*(d.state))
-	moveJSON, err := json.Marshal(&move)
-	if err != nil {
-		return []byte{}, fmt.Errorf("JSON encode failed: %+v err: %s", move, err)
-	}
-	return moveJSON, nil
+	return nil
 }
 
 func (d *Driver) initState(message liarsdice.MessageSetup) *State {
@@ -134,6 +216,9 @@ func (d *Driver) initState(message liarsdice.MessageSetup) *State {
 	for _, p := range message.Players {
 		players = append(players, *p)
 		diceCounts[p.ID] = 5
+		if count, ok := message.DiceCounts[p.ID]; ok {
+			diceCounts[p.ID] = count
+		}
 		diceShown[p.ID] = []liarsdice.DiceVal{}
 	}
 
@@ -40,3 +40,61 @@ func TestSetup(t *testing.T) {
 		t.Fatalf("got unexpected handleSetup output: %s", output)
 	}
 }
+
+type gameOverTestAI struct {
+	state    State
+	gameOver *game.MessageGameOver
+}
+
+func (ai *gameOverTestAI) GetMove(state State) liarsdice.Move {
+	return liarsdice.Move{}
+}
+
+func (ai *gameOverTestAI) GameOver(state State, gameOver game.MessageGameOver) {
+	ai.state = state
+	ai.gameOver = &gameOver
+}
+
+func TestGameOver(t *testing.T) {
+	ai := &gameOverTestAI{}
+	d := New(ai)
+	d.state = d.initState(liarsdice.MessageSetup{
+		ID:       1,
+		Position: 1,
+		Players:  []*liarsdice.Player{{Player: game.Player{ID: 1}}, {Player: game.Player{ID: 2}}},
+	})
+
+	roll, _ := json.Marshal(liarsdice.EventRoll{ID: 2, Dice: []liarsdice.DiceVal{3, 4}})
+	challenge, _ := json.Marshal(liarsdice.EventChallenge{ID: 2, DiceChange: map[game.PlayerID]int{1: -5}})
+	m := game.MessageGameOver{
+		Termination: game.TerminationNormal,
+		Places:      []game.Place{{Player: game.Player{ID: 2}, Rank: 1}, {Player: game.Player{ID: 1}, Rank: 2}},
+		NewEvents:   []game.Event{{Type: liarsdice.EventTypeChallenge, Data: challenge}},
+		Revealed:    []game.Event{{Type: liarsdice.EventTypeRoll, Data: roll}},
+	}
+	mJSON, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("error marshaling game over: %s", err)
+	}
+
+	output, err := d.handleGameOver(mJSON)
+	if err != nil {
+		t.Fatalf("error handling game over: %s", err)
+	}
+	if string(output) != "\"OK\"" {
+		t.Fatalf("got unexpected handleGameOver output: %s", output)
+	}
+
+	if ai.gameOver == nil {
+		t.Fatalf("AI wasn't told the game is over")
+	}
+	if ai.state.DiceCounts[1] != 0 {
+		t.Errorf("final events weren't applied, dice counts: %v", ai.state.DiceCounts)
+	}
+	if len(ai.state.Dice) != 0 {
+		t.Errorf("revealed roll was taken as our own: %v", ai.state.Dice)
+	}
+	if len(ai.gameOver.Revealed) != 1 || ai.gameOver.Places[0].Player.ID != 2 {
+		t.Errorf("got unexpected game over: %+v", ai.gameOver)
+	}
+}
@@ -1,7 +1,33 @@
 package driver
 
-import "github.com/boardgamesai/games/liarsdice"
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/liarsdice"
+)
 
 type liarsdiceAI interface {
 	GetMove(state State) liarsdice.Move
 }
+
+// gameOverAI is optional: an AI that implements it hears how the game ended, and what was hidden
+// from it, before it's shut down.
+type gameOverAI interface {
+	GameOver(state State, gameOver game.MessageGameOver)
+}
+
+// annotatingAI is optional: see game.Annotation.
+type annotatingAI interface {
+	Annotation() *game.Annotation
+}
+
+// warmingUpAI is optional: see game.MessageWarmUp.
+type warmingUpAI interface {
+	WarmUp(ctx context.Context, state State)
+}
+
+// ponderingAI is optional: see game.AIDriver.StartPondering.
+type ponderingAI interface {
+	Ponder(ctx context.Context, state State)
+}
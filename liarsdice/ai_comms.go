@@ -1,6 +1,13 @@
 package liarsdice
 
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
 type AIComms interface {
-	Setup(p *Player, players []*Player) error
-	GetMove(p *Player) (Move, error)
+	Setup(ctx context.Context, p *Player, players []*Player, diceCounts map[game.PlayerID]int) error
+	GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, error)
+	GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error
 }
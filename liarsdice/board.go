@@ -2,8 +2,11 @@ package liarsdice
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/game/elements/dice"
+	"github.com/boardgamesai/games/util"
 )
 
 type ChallengeOutcome struct {
@@ -19,21 +22,29 @@ type Board struct {
 	DiceHidden map[*Player]*Dice
 	DiceShown  map[*Player][]DiceVal
 	Outcome    *ChallengeOutcome
+	players    []*Player // In seat order, so rolls happen in a repeatable order
+	rng        *util.Rand
 }
 
 func NewBoard(players []*Player) *Board {
+	return newBoard(players, util.NewRand(util.RandSeed()))
+}
+
+func newBoard(players []*Player, rng *util.Rand) *Board {
 	hidden := map[*Player]*Dice{}
 	shown := map[*Player][]DiceVal{}
 
 	for _, p := range players {
 		hidden[p] = &Dice{dice.New(5, diceVals)}
-		hidden[p].Roll()
+		hidden[p].RollWith(rng)
 		shown[p] = []DiceVal{}
 	}
 
 	return &Board{
 		DiceHidden: hidden,
 		DiceShown:  shown,
+		players:    players,
+		rng:        rng,
 	}
 }
 
@@ -50,8 +61,14 @@ func (b *Board) DiceForPlayer(p *Player) []DiceVal {
 }
 
 func (b *Board) IsValidMove(m Move) error {
+	return IsValidBid(m, b.Bid, b.Quantity, len(b.AllDice()))
+}
+
+// IsValidBid checks a move against the current bid and quantity, with totalDice still in play. It doesn't
+// need the whole board, so players can use it with what they know.
+func IsValidBid(m Move, bid DiceVal, quantity, totalDice int) error {
 	if m.Challenge {
-		if b.Quantity == 0 {
+		if quantity == 0 {
 			// Can't challenge when no one has made an opening bid yet
 			return IllegalMoveError{m}
 		}
@@ -60,29 +77,29 @@ func (b *Board) IsValidMove(m Move) error {
 		return nil
 	}
 
-	if m.Bid <= 0 || m.Bid > 6 || m.Quantity <= 0 || m.Quantity > len(b.AllDice()) {
+	if m.Bid <= 0 || m.Bid > 6 || m.Quantity <= 0 || m.Quantity > totalDice {
 		return OutOfBoundsError{m}
 	}
 
 	if m.Bid == Star {
-		if b.Bid == Star {
-			if m.Quantity <= b.Quantity {
+		if bid == Star {
+			if m.Quantity <= quantity {
 				return IllegalMoveError{m}
 			}
 		} else {
-			if m.Quantity*2 <= b.Quantity {
+			if m.Quantity*2 <= quantity {
 				return IllegalMoveError{m}
 			}
 		}
 	} else {
-		if b.Bid == Star {
-			if b.Quantity*2 > m.Quantity {
+		if bid == Star {
+			if quantity*2 > m.Quantity {
 				return IllegalMoveError{m}
 			}
 		} else {
-			if m.Quantity == b.Quantity && m.Bid <= b.Bid {
+			if m.Quantity == quantity && m.Bid <= bid {
 				return IllegalMoveError{m}
-			} else if m.Bid == b.Bid && m.Quantity <= b.Quantity {
+			} else if m.Bid == bid && m.Quantity <= quantity {
 				return IllegalMoveError{m}
 			}
 		}
@@ -91,6 +108,36 @@ func (b *Board) IsValidMove(m Move) error {
 	return nil
 }
 
+// PossibleMoves is every bid that beats the current one, followed by a challenge if there's a bid to
+// challenge. Any bid can also show dice, but those variations are left out since there are so many.
+func (b *Board) PossibleMoves() []Move {
+	return PossibleBids(b.Bid, b.Quantity, len(b.AllDice()))
+}
+
+// PossibleBids lists every legal move against the current bid and quantity, lowest quantity first,
+// along with a challenge if there's a bid to challenge. Like IsValidBid, it doesn't need the whole board.
+func PossibleBids(bid DiceVal, quantity, totalDice int) []Move {
+	moves := []Move{}
+
+	for q := 1; q <= totalDice; q++ {
+		for _, face := range diceVals {
+			m := Move{
+				Bid:      face,
+				Quantity: q,
+			}
+			if IsValidBid(m, bid, quantity, totalDice) == nil {
+				moves = append(moves, m)
+			}
+		}
+	}
+
+	if quantity > 0 {
+		moves = append(moves, Move{Challenge: true})
+	}
+
+	return moves
+}
+
 func (b *Board) IsValidShow(m Move, p *Player) error {
 	// You must have at least one die left to roll
 	if len(m.ShowDice) >= b.DiceHidden[p].Count() {
@@ -152,7 +199,7 @@ func (b *Board) applyBidMove(m Move, p *Player) {
 		b.moveShownDice(m, p)
 
 		// Re-roll the dice this player has left
-		b.DiceHidden[p].Roll()
+		b.DiceHidden[p].RollWith(b.rng)
 	}
 }
 
@@ -197,8 +244,8 @@ func (b *Board) applyChallengeMove(p *Player) {
 	b.Quantity = 0
 
 	// Re-roll everyone's dice
-	for _, d := range b.DiceHidden {
-		d.Roll()
+	for _, p := range b.players {
+		b.DiceHidden[p].RollWith(b.rng)
 	}
 }
 
@@ -259,3 +306,28 @@ func min(n1, n2 int) int {
 	}
 	return n2
 }
+
+// HashFor fingerprints what the player knows of the board, so their driver can check its copy against ours.
+func (b *Board) HashFor(p *Player) string {
+	diceCounts := map[game.PlayerID]int{}
+	for _, player := range b.players {
+		diceCounts[player.ID] = len(b.DiceForPlayer(player))
+	}
+	return Hash(b.DiceHidden[p].Values, b.Bid, b.Quantity, diceCounts)
+}
+
+// Hash fingerprints a player's unshown dice, in any order, along with the current bid and how many
+// dice everyone has left.
+func Hash(dice []DiceVal, bid DiceVal, quantity int, diceCounts map[game.PlayerID]int) string {
+	sorted := append([]DiceVal{}, dice...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return game.Hash(struct {
+		Dice       []DiceVal
+		Bid        DiceVal
+		Quantity   int
+		DiceCounts map[game.PlayerID]int
+	}{sorted, bid, quantity, diceCounts})
+}
@@ -69,6 +69,46 @@ func TestIsValidMove(t *testing.T) {
 	}
 }
 
+func TestPossibleMoves(t *testing.T) {
+	tests := []struct {
+		prevBid      DiceVal
+		prevQuantity int
+		expected     int
+		challenge    bool
+	}{
+		{0, 0, 60, false},   // Anything goes for the opening bid
+		{Star, 5, 11, true}, // 6-10 stars, or 10 of anything else
+		{Star, 8, 3, true},  // 9 or 10 stars, anything else would need 16
+		{Star, 10, 1, true}, // Only a challenge is left
+	}
+
+	players := []*Player{
+		newPlayer(1),
+		newPlayer(2),
+	}
+
+	for _, test := range tests {
+		b := NewBoard(players)
+		b.Bid = test.prevBid
+		b.Quantity = test.prevQuantity
+
+		moves := b.PossibleMoves()
+		if len(moves) != test.expected {
+			t.Errorf("prevBid: %s prevQuantity: %d expected %d moves, got %d: %v", test.prevBid, test.prevQuantity, test.expected, len(moves), moves)
+			continue
+		}
+
+		for _, m := range moves {
+			if err := b.IsValidMove(m); err != nil {
+				t.Errorf("prevBid: %s prevQuantity: %d got invalid move %s: %s", test.prevBid, test.prevQuantity, m, err)
+			}
+		}
+		if hasChallenge := len(moves) > 0 && moves[len(moves)-1].Challenge; hasChallenge != test.challenge {
+			t.Errorf("prevBid: %s prevQuantity: %d expected challenge %t, got %t", test.prevBid, test.prevQuantity, test.challenge, hasChallenge)
+		}
+	}
+}
+
 func TestIsValidShow(t *testing.T) {
 	tests := []struct {
 		playerDice []DiceVal
@@ -190,3 +230,29 @@ func TestDiceChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestHashFor(t *testing.T) {
+	players := []*Player{
+		newPlayer(1),
+		newPlayer(2),
+	}
+	b := NewBoard(players)
+	b.Bid = 3
+	b.Quantity = 2
+	b.DiceShown[players[1]] = []DiceVal{3}
+
+	// This is what player 1's driver knows
+	dice := append([]DiceVal{}, b.DiceHidden[players[0]].Values...)
+	dice[0], dice[len(dice)-1] = dice[len(dice)-1], dice[0]
+	diceCounts := map[game.PlayerID]int{1: 5, 2: 6}
+
+	if b.HashFor(players[0]) != Hash(dice, 3, 2, diceCounts) {
+		t.Errorf("Expected the driver's hash to match")
+	}
+	if b.HashFor(players[0]) == Hash(dice, 3, 3, diceCounts) {
+		t.Errorf("Expected a different bid to hash differently")
+	}
+	if b.HashFor(players[0]) == b.HashFor(players[1]) && !reflect.DeepEqual(b.DiceHidden[players[0]].Values, b.DiceHidden[players[1]].Values) {
+		t.Errorf("Expected players with different dice to get different hashes")
+	}
+}
@@ -1,6 +1,7 @@
 package liarsdice
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/boardgamesai/games/game"
@@ -12,28 +13,30 @@ type Comms struct {
 
 func NewComms(g *Game) *Comms {
 	return &Comms{
-		Comms: game.Comms{
-			EventLog: &(g.EventLog),
-		},
+		Comms: g.BaseComms(),
 	}
 }
 
-func (c *Comms) Setup(p *Player, players []*Player) error {
+func (c *Comms) Setup(ctx context.Context, p *Player, players []*Player, diceCounts map[game.PlayerID]int) error {
 	message := MessageSetup{
-		ID:       p.ID,
-		Position: p.Position,
-		Players:  players,
+		ID:         p.ID,
+		Position:   p.Position,
+		Players:    players,
+		DiceCounts: diceCounts,
 	}
-	return p.SendMessageNoResponse(message)
+	return c.SendMessageNoResponse(ctx, &p.Player, message)
 }
 
-func (c *Comms) GetMove(p *Player) (Move, error) {
+func (c *Comms) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, error) {
 	move := Move{}
 
 	message := MessageMove{
-		NewEvents: c.NewEvents(p.ID),
+		NewEvents:  c.NewEvents(p.ID),
+		Hash:       hash,
+		LegalMoves: legalMoves,
+		State:      state,
 	}
-	responseJSON, err := p.SendMessage(message)
+	responseJSON, err := c.SendMessage(ctx, &p.Player, message)
 	if err != nil {
 		return move, err
 	}
@@ -41,3 +44,7 @@ func (c *Comms) GetMove(p *Player) (Move, error) {
 	err = json.Unmarshal(responseJSON, &move)
 	return move, err
 }
+
+func (c *Comms) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return c.SendGameOver(ctx, &p.Player, termination, places)
+}
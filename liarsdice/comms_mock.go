@@ -0,0 +1,33 @@
+package liarsdice
+
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// CommsMock plays every player with the random fallback AI, except for positions set to DQ.
+type CommsMock struct {
+	g  *Game
+	dq map[int]bool
+}
+
+func (c *CommsMock) Setup(ctx context.Context, p *Player, players []*Player, diceCounts map[game.PlayerID]int) error {
+	return nil
+}
+
+func (c *CommsMock) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, error) {
+	if c.dq[p.Position] {
+		return Move{}, game.DQError{
+			Type: game.DQTypeRuntime,
+			Msg:  "mock panic",
+		}
+	}
+
+	b := c.g.Board
+	return RandomAI{}.GetMove(b.DiceHidden[p].Values, b.Bid, b.Quantity, len(b.AllDice())), nil
+}
+
+func (c *CommsMock) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return nil
+}
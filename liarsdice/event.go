@@ -1,6 +1,7 @@
 package liarsdice
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -20,7 +21,8 @@ type EventSetupPlayer struct {
 }
 
 type EventSetup struct {
-	Players []EventSetupPlayer
+	Players       []EventSetupPlayer
+	StartPosition string `json:",omitempty"`
 }
 
 func (e EventSetup) String() string {
@@ -28,7 +30,12 @@ func (e EventSetup) String() string {
 	for _, p := range e.Players {
 		players = append(players, fmt.Sprintf("%+v", p))
 	}
-	return "Setup " + strings.Join(players, ", ")
+
+	s := "Setup " + strings.Join(players, ", ")
+	if e.StartPosition != "" {
+		s += " from position " + e.StartPosition
+	}
+	return s
 }
 
 type EventRoll struct {
@@ -65,3 +72,31 @@ func (e EventChallenge) String() string {
 	s = strings.TrimSpace(s)
 	return fmt.Sprintf("ID %d challenge actual: %d dice change: %s", e.ID, e.ActualQuantity, s)
 }
+
+// DecodeEvent turns a logged event back into the type it was logged as, e.g. for displaying it.
+func DecodeEvent(e game.Event) fmt.Stringer {
+	var eStr fmt.Stringer
+
+	switch e.Type {
+	case EventTypeSetup:
+		ce := EventSetup{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeMove:
+		ce := EventMove{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeChallenge:
+		ce := EventChallenge{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	case EventTypeRoll:
+		ce := EventRoll{}
+		json.Unmarshal(e.Data, &ce)
+		eStr = ce
+	default:
+		eStr = game.CommonEvent(e)
+	}
+
+	return eStr
+}
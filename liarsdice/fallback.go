@@ -0,0 +1,34 @@
+package liarsdice
+
+import "github.com/boardgamesai/games/util"
+
+// FallbackAI plays in-process for a player who was disqualified, when substitutes are enabled.
+// It gets the player's own dice, the current bid (a quantity of 0 means no bid yet) and the
+// number of dice left in play.
+type FallbackAI interface {
+	GetMove(dice []DiceVal, bid DiceVal, quantity int, totalDice int) Move
+}
+
+// RandomAI either raises the current bid by one or challenges it, at random.
+type RandomAI struct{}
+
+func (ai RandomAI) GetMove(dice []DiceVal, bid DiceVal, quantity int, totalDice int) Move {
+	if quantity == 0 {
+		// We have to open, so bid one of something we're holding
+		return Move{
+			Bid:      dice[util.RandInt(0, len(dice)-1)],
+			Quantity: 1,
+		}
+	}
+
+	if quantity >= totalDice || util.RandInt(1, 4) == 1 {
+		return Move{
+			Challenge: true,
+		}
+	}
+
+	return Move{
+		Bid:      bid,
+		Quantity: quantity + 1,
+	}
+}
@@ -1,8 +1,9 @@
 package liarsdice
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/util"
@@ -10,6 +11,7 @@ import (
 
 type Game struct {
 	game.Game[*Player, *Board, AIComms]
+	Fallback FallbackAI // Plays for DQ'd players when substitutes are enabled, defaults to RandomAI
 }
 
 func New() *Game {
@@ -20,26 +22,58 @@ func New() *Game {
 }
 
 func (g *Game) Play() error {
+	return g.PlayContext(context.Background())
+}
+
+// PlayContext plays the game until it's over, or until ctx is done, in which case the game is aborted.
+func (g *Game) PlayContext(ctx context.Context) error {
+	return g.Finish(ctx, g.play(ctx, nil))
+}
+
+func (g *Game) play(ctx context.Context, s *game.Snapshot) (err error) {
 	// Wipe out any previous state
 	g.reset()
-	g.shufflePlayers()
+
+	playerTurn := 0
+	if s == nil {
+		g.seatPlayers()
+
+		// Roll once everyone is seated, so a given seed always gives a seat the same dice.
+		g.Board = newBoard(g.Players, g.Rand())
+		if startDice, turn := g.startPosition(); startDice != nil {
+			for i, p := range g.Players {
+				g.Board.DiceHidden[p].Values = startDice[i]
+			}
+			playerTurn = turn
+		}
+	} else {
+		var err error
+		if playerTurn, err = g.restore(s); err != nil {
+			return err
+		}
+	}
+	g.Start()
 
 	// We need to write down our setup
 	setupEvent := EventSetup{
-		Players: []EventSetupPlayer{},
+		Players:       []EventSetupPlayer{},
+		StartPosition: g.StartPosition(),
 	}
 
+	// Players need to know how many dice everyone starts with, even when resuming.
+	diceCounts := g.startDiceCounts()
+
 	// Launch the player processes
 	for _, player := range g.Players {
 		defer player.CleanUp()
 		defer g.SetOutput(player.ID, player)
 
-		err := player.Run()
+		err := player.Run(ctx)
 		if err != nil {
 			return fmt.Errorf("player %s failed to run, err: %s", player, err)
 		}
 
-		err = g.Comms.Setup(player, g.Players)
+		err = g.Comms.Setup(ctx, player, g.Players, diceCounts)
 		if err != nil {
 			return fmt.Errorf("player %s failed to setup, err: %s", player, err)
 		}
@@ -52,34 +86,39 @@ func (g *Game) Play() error {
 		setupEvent.Players = append(setupEvent.Players, esp)
 	}
 
-	g.EventLog.AddNone(setupEvent)
-	g.sendRollEvents()
+	if s == nil {
+		g.AddNone(setupEvent)
+		g.sendRollEvents()
+	}
+
+	// Let everyone know how it went before they're shut down.
+	defer func() {
+		g.GameOver(ctx, err, func(ctx context.Context, p *Player, termination game.Termination) error {
+			return g.Comms.GameOver(ctx, p, termination, g.Places())
+		})
+	}()
+
+	// Everyone warms up before the first move, one at a time
+	for _, player := range g.Players {
+		if err := g.WarmUp(ctx, player); err != nil && !g.Substitute(player, err) {
+			g.setLoser(player)
+			return err
+		}
+	}
 
-	playerTurn := 0
 	for !g.gameOver() {
+		g.checkpoint(playerTurn)
+
 		player := g.Players[playerTurn]
-		move, err := g.Comms.GetMove(player)
+		move, err := g.getMove(ctx, player)
 		if err != nil {
 			g.setLoser(player)
-			switch e := err.(type) {
-			// If this is a DQError, we need to augment it with the player ID,
-			// which we may not know about where the error occurred
-			case game.DQError:
-				return g.AddDQErrorID(&e, player.ID)
-			case *game.DQError:
-				return g.AddDQErrorID(e, player.ID)
-			}
 			return err
 		}
 
-		err = g.Board.ApplyMove(move, player)
-		if err != nil {
-			g.setLoser(player)
-			return game.DQError{
-				ID:   player.ID,
-				Type: game.DQTypeInvalidMove,
-				Msg:  err.Error(),
-			}
+		// The move has already been validated, so if this fails it's our bug, not the player's.
+		if err := g.Board.ApplyMove(move, player); err != nil {
+			return fmt.Errorf("couldn't apply validated move %s for ID %d: %s", move, player.ID, err)
 		}
 
 		if !move.Challenge {
@@ -88,7 +127,7 @@ func (g *Game) Play() error {
 				ID:   player.ID,
 				Move: move,
 			}
-			g.EventLog.AddAll(e)
+			g.AddAll(e)
 
 			// Did dice get shown here? If so we need to send an event about the remaining dice re-roll
 			// (the board already did the re-roll)
@@ -97,7 +136,7 @@ func (g *Game) Play() error {
 					ID:   player.ID,
 					Dice: g.Board.DiceHidden[player].Values,
 				}
-				g.EventLog.Add(e, []game.PlayerID{player.ID})
+				g.Add(e, []game.PlayerID{player.ID})
 			}
 		} else {
 			// Challenge will result in dice changes / potential eliminations
@@ -121,7 +160,7 @@ func (g *Game) Play() error {
 			if eliminated != nil {
 				e.Eliminated = eliminated.ID
 			}
-			g.EventLog.AddAll(e)
+			g.AddAll(e)
 
 			if eliminated != nil {
 				place := game.Place{
@@ -136,11 +175,32 @@ func (g *Game) Play() error {
 			}
 		}
 
+		// Out of plies, so whoever has the most dice left wins
+		if g.AddPly() && !g.gameOver() {
+			g.adjudicate()
+			return nil
+		}
+
 		playerTurn = util.Increment(playerTurn, 0, g.MetaData().NumPlayers-1)
 		for g.Board.DiceHidden[g.Players[playerTurn]].Count() == 0 {
 			// Skip over eliminated players
 			playerTurn = util.Increment(playerTurn, 0, g.MetaData().NumPlayers-1)
 		}
+
+		// Everyone still in but the next to bid can think while they do
+		if g.gameOver() {
+			continue
+		}
+		pondering := []*Player{}
+		for _, p := range g.Players {
+			if p != g.Players[playerTurn] && g.Board.DiceHidden[p].Count() > 0 {
+				pondering = append(pondering, p)
+			}
+		}
+		if p, err := g.Ponder(ctx, pondering); err != nil && !g.Substitute(p, err) {
+			g.setLoser(p)
+			return err
+		}
 	}
 
 	// We're done, whoever's left with dice is the winner.
@@ -155,6 +215,94 @@ func (g *Game) Play() error {
 		}
 	}
 
+	// This sorts the places, and moves anyone who was DQ'd to the bottom.
+	g.SetPlaces(g.Places())
+
+	return nil
+}
+
+// playerState is sent along with the move request to players who asked for the full state.
+func (g *Game) playerState(p *Player) *PlayerState {
+	if !p.FullState {
+		return nil
+	}
+
+	state := PlayerState{
+		ID:         p.ID,
+		Position:   p.Position,
+		Players:    g.Players,
+		Dice:       g.Board.DiceHidden[p].Values,
+		Bid:        g.Board.Bid,
+		Quantity:   g.Board.Quantity,
+		DiceCounts: map[game.PlayerID]int{},
+		DiceShown:  map[game.PlayerID][]DiceVal{},
+	}
+	if g.Board.Bidder != nil {
+		state.Bidder = g.Board.Bidder.ID
+	}
+	for _, player := range g.Players {
+		state.DiceCounts[player.ID] = len(g.Board.DiceForPlayer(player))
+		state.DiceShown[player.ID] = g.Board.DiceShown[player]
+	}
+
+	return &state
+}
+
+// legalMoves is sent along with the move request to players who asked for it. It's never nil for them,
+// so having no legal moves can't be mistaken for not having asked.
+func (g *Game) legalMoves(p *Player) []Move {
+	if !p.LegalMoves {
+		return nil
+	}
+	return append([]Move{}, g.Board.PossibleMoves()...)
+}
+
+func (g *Game) getMove(ctx context.Context, player *Player) (Move, error) {
+	if g.IsSubstitute(player.ID) {
+		move := g.fallback().GetMove(g.Board.DiceHidden[player].Values, g.Board.Bid, g.Board.Quantity, len(g.Board.AllDice()))
+		if err := g.isValidMove(move, player); err != nil {
+			return move, fmt.Errorf("fallback AI made an invalid move: %s", err)
+		}
+		return move, nil
+	}
+
+	move, err := g.Comms.GetMove(ctx, player, g.Board.HashFor(player), g.legalMoves(player), g.playerState(player))
+	if err == nil {
+		if err = g.isValidMove(move, player); err == nil {
+			return move, nil
+		}
+
+		err = &game.DQError{
+			ID:   player.ID,
+			Type: game.DQTypeInvalidMove,
+			Msg:  err.Error(),
+		}
+	}
+
+	// If this is a DQError, we need to augment it with the player ID,
+	// which we may not know about where the error occurred
+	switch e := err.(type) {
+	case game.DQError:
+		err = g.AddDQErrorID(&e, player.ID)
+	case *game.DQError:
+		err = g.AddDQErrorID(e, player.ID)
+	}
+
+	if g.Substitute(player, err) {
+		return g.getMove(ctx, player)
+	}
+	return move, err
+}
+
+func (g *Game) isValidMove(m Move, p *Player) error {
+	if err := g.Board.IsValidMove(m); err != nil {
+		return err
+	}
+
+	if len(m.ShowDice) > 0 {
+		return g.Board.IsValidShow(m, p)
+	}
+
 	return nil
 }
 
@@ -166,37 +314,22 @@ func (g *Game) sendRollEvents() {
 				ID:   p.ID,
 				Dice: d.Values,
 			}
-			g.EventLog.Add(e, []game.PlayerID{p.ID})
+			g.Add(e, []game.PlayerID{p.ID})
 		}
 	}
 }
 
 func (g *Game) Events() []fmt.Stringer {
-	events := make([]fmt.Stringer, len(g.EventLog))
-
-	for i, event := range g.EventLog {
-		var eStr fmt.Stringer
+	return g.EventsFor(game.ViewFull)
+}
 
-		switch event.Type {
-		case EventTypeSetup:
-			e := EventSetup{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeMove:
-			e := EventMove{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeChallenge:
-			e := EventChallenge{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		case EventTypeRoll:
-			e := EventRoll{}
-			json.Unmarshal(event.Data, &e)
-			eStr = e
-		}
+// EventsFor describes the events seen through v.
+func (g *Game) EventsFor(v game.View) []fmt.Stringer {
+	rawEvents := g.RawEventsFor(v)
+	events := make([]fmt.Stringer, len(rawEvents))
 
-		events[i] = eStr
+	for i, event := range rawEvents {
+		events[i] = DecodeEvent(event)
 	}
 
 	return events
@@ -204,15 +337,25 @@ func (g *Game) Events() []fmt.Stringer {
 
 func (g *Game) reset() {
 	g.Game.Reset()
-	g.Board = NewBoard(g.Players)
 	if g.Comms == nil {
 		g.Comms = NewComms(g)
 	}
 }
 
-func (g *Game) shufflePlayers() {
-	util.Shuffle(g.Players)
+func (g *Game) fallback() FallbackAI {
+	if g.Fallback == nil {
+		return RandomAI{}
+	}
+	return g.Fallback
+}
+
+func (g *Game) seatPlayers() {
+	g.SeatPlayers()
+	g.assignSeats()
+}
 
+// assignSeats sets up each player according to their place in the turn order.
+func (g *Game) assignSeats() {
 	for i := 1; i <= 4; i++ {
 		g.Players[i-1].Position = i
 	}
@@ -230,20 +373,80 @@ func (g *Game) gameOver() bool {
 }
 
 func (g *Game) setLoser(p *Player) {
-	places := []game.Place{}
-
+	// Anyone still in the game is ranked ahead of the players already eliminated.
+	remaining := []*Player{}
 	for _, player := range g.Players {
-		rank := 1
-		if player == p {
-			// Last place
-			rank = 4
+		if player != p && g.Board.DiceHidden[player].Count() > 0 {
+			remaining = append(remaining, player)
 		}
+	}
 
-		places = append(places, game.Place{
-			Player: player.Player,
-			Rank:   rank,
-		})
+	places := g.rankByDice(remaining)
+	if g.Options().DQRanking == game.DQRankShared {
+		for i := range places {
+			places[i].Rank = 1
+			places[i].Tie = len(remaining) > 1
+		}
 	}
 
+	// Eliminated players keep their order, but each moves up one spot since the DQ'd player,
+	// who outlasted them, goes to the bottom.
+	for _, place := range g.Places() {
+		place.Rank--
+		places = append(places, place)
+	}
+
+	places = append(places, game.Place{
+		Player: p.Player,
+		Rank:   g.MetaData().NumPlayers,
+	})
+
 	g.SetPlaces(places)
 }
+
+// rankByDice places players still in the game by how many dice they have left, most first, sharing a rank
+// when they have the same number.
+func (g *Game) rankByDice(players []*Player) []game.Place {
+	// We do stable so that game order is maintained among tied players.
+	players = append([]*Player{}, players...)
+	sort.SliceStable(players, func(i, j int) bool {
+		return len(g.Board.DiceForPlayer(players[i])) > len(g.Board.DiceForPlayer(players[j]))
+	})
+
+	places := []game.Place{}
+	for i, player := range players {
+		place := game.Place{
+			Player: player.Player,
+			Rank:   i + 1,
+		}
+
+		if i > 0 && len(g.Board.DiceForPlayer(player)) == len(g.Board.DiceForPlayer(players[i-1])) {
+			place.Rank = places[i-1].Rank
+			place.Tie = true
+			places[i-1].Tie = true
+		}
+
+		places = append(places, place)
+	}
+
+	return places
+}
+
+// adjudicate decides a game that reached the MaxPlies option on how many dice everyone has left.
+// Players already eliminated keep their places.
+func (g *Game) adjudicate() {
+	remaining := []*Player{}
+	e := game.EventAdjudication{
+		Reason: g.PlyCapReason(),
+		Basis:  "dice left",
+	}
+	for _, p := range g.Players {
+		if g.Board.DiceHidden[p].Count() > 0 {
+			remaining = append(remaining, p)
+			e.Scores = append(e.Scores, game.AdjudicationScore{ID: p.ID, Score: len(g.Board.DiceForPlayer(p))})
+		}
+	}
+
+	g.SetPlaces(append(g.rankByDice(remaining), g.Places()...))
+	g.Adjudicate(e)
+}
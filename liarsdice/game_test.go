@@ -0,0 +1,249 @@
+package liarsdice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/dice"
+)
+
+func getGame(diceCounts []int) *Game {
+	g := New()
+	for i := 0; i < g.MetaData().NumPlayers; i++ {
+		g.Players[i] = newPlayer(i + 1)
+		g.Players[i].Runnable = &game.RunnablePlayerMock{}
+	}
+
+	g.Board = NewBoard(g.Players)
+	for i, count := range diceCounts {
+		g.Board.DiceHidden[g.Players[i]] = &Dice{dice.New(count, diceVals)}
+	}
+
+	return g
+}
+
+func TestSetLoser(t *testing.T) {
+	tests := []struct {
+		ranking    game.DQRanking
+		diceCounts []int
+		eliminated []int // In order of elimination
+		dq         int
+		ranks      []int // In player order
+		ties       []bool
+	}{
+		{game.DQRankStandings, []int{5, 3, 4, 2}, []int{}, 1, []int{4, 2, 1, 3}, []bool{false, false, false, false}},
+		{game.DQRankStandings, []int{2, 0, 2, 4}, []int{2}, 4, []int{1, 3, 1, 4}, []bool{true, false, true, false}},
+		{game.DQRankStandings, []int{0, 0, 3, 1}, []int{1, 2}, 4, []int{3, 2, 1, 4}, []bool{false, false, false, false}},
+		{game.DQRankShared, []int{5, 3, 0, 2}, []int{3}, 2, []int{1, 4, 3, 1}, []bool{true, false, false, true}},
+	}
+
+	for _, test := range tests {
+		g := getGame(test.diceCounts)
+		g.SetOptions(game.Options{DQRanking: test.ranking})
+		for _, id := range test.eliminated {
+			g.AddPlace(game.Place{
+				Player: g.Players[id-1].Player,
+				Rank:   g.MetaData().NumPlayers - len(g.Places()),
+			})
+		}
+
+		g.setLoser(g.Players[test.dq-1])
+
+		places := g.Places()
+		if len(places) != g.MetaData().NumPlayers {
+			t.Fatalf("expected %d places, got: %+v", g.MetaData().NumPlayers, places)
+		}
+		for _, place := range places {
+			i := int(place.Player.ID) - 1
+			if place.Rank != test.ranks[i] || place.Tie != test.ties[i] {
+				t.Errorf("ranking: %s dice: %v dq: %d got place: %s expected rank: %d tie: %t",
+					test.ranking, test.diceCounts, test.dq, place, test.ranks[i], test.ties[i])
+			}
+		}
+	}
+}
+
+func TestAdjudicate(t *testing.T) {
+	tests := []struct {
+		diceCounts []int
+		eliminated []int // In order of elimination
+		ranks      []int // In player order
+		ties       []bool
+	}{
+		{[]int{5, 3, 4, 2}, []int{}, []int{1, 3, 2, 4}, []bool{false, false, false, false}},
+		{[]int{2, 0, 2, 4}, []int{2}, []int{2, 4, 2, 1}, []bool{true, false, true, false}},
+		{[]int{0, 0, 3, 3}, []int{2, 1}, []int{3, 4, 1, 1}, []bool{false, false, true, true}},
+	}
+
+	for _, test := range tests {
+		g := getGame(test.diceCounts)
+		g.SetOptions(game.Options{MaxPlies: 10})
+		for _, id := range test.eliminated {
+			g.AddPlace(game.Place{
+				Player: g.Players[id-1].Player,
+				Rank:   g.MetaData().NumPlayers - len(g.Places()),
+			})
+		}
+
+		g.adjudicate()
+
+		places := g.Places()
+		if len(places) != g.MetaData().NumPlayers {
+			t.Fatalf("expected %d places, got: %+v", g.MetaData().NumPlayers, places)
+		}
+		for _, place := range places {
+			i := int(place.Player.ID) - 1
+			if place.Rank != test.ranks[i] || place.Tie != test.ties[i] {
+				t.Errorf("dice: %v got place: %s expected rank: %d tie: %t",
+					test.diceCounts, place, test.ranks[i], test.ties[i])
+			}
+		}
+		events := g.Events()
+		if _, ok := events[len(events)-1].(game.EventAdjudication); !ok {
+			t.Errorf("expected the last event to be an adjudication, got: %s", events[len(events)-1])
+		}
+	}
+}
+
+func TestMaxPlies(t *testing.T) {
+	g := getGame([]int{})
+	g.Comms = &CommsMock{g: g}
+	g.SetOptions(game.Options{MaxPlies: 3})
+
+	if err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if g.Termination() != game.TerminationAdjudicated {
+		t.Errorf("expected adjudicated termination, got: %s", g.Termination())
+	}
+	if g.Plies() != 3 {
+		t.Errorf("expected 3 plies, got: %d", g.Plies())
+	}
+	if len(g.Places()) != g.MetaData().NumPlayers {
+		t.Errorf("expected %d places, got: %+v", g.MetaData().NumPlayers, g.Places())
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	for _, substitute := range []bool{false, true} {
+		g := getGame([]int{})
+		g.Comms = &CommsMock{
+			g:  g,
+			dq: map[int]bool{3: true},
+		}
+		g.SetOptions(game.Options{Substitute: substitute})
+
+		err := g.Play()
+		dqID := g.Players[2].ID
+		if !substitute {
+			if err == nil {
+				t.Errorf("expected DQ error")
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !g.IsSubstitute(dqID) {
+			t.Errorf("expected player %d to be substituted", dqID)
+		}
+
+		places := g.Places()
+		if len(places) != g.MetaData().NumPlayers {
+			t.Fatalf("expected %d places, got: %+v", g.MetaData().NumPlayers, places)
+		}
+		for i, place := range places {
+			if place.Rank != i+1 || place.Tie {
+				t.Errorf("expected rank %d with no tie, got: %s", i+1, place)
+			}
+		}
+		if places[len(places)-1].Player.ID != dqID {
+			t.Errorf("expected player %d to finish last, got: %+v", dqID, places)
+		}
+	}
+}
+
+type checkpointObserver struct {
+	game.NopObserver
+	snapshots []*game.Snapshot
+}
+
+func (o *checkpointObserver) OnCheckpoint(s *game.Snapshot) {
+	o.snapshots = append(o.snapshots, s)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	g := getGame([]int{})
+	g.Comms = &CommsMock{g: g}
+	o := &checkpointObserver{}
+	g.AddObserver(o)
+
+	if err := g.Play(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(o.snapshots) < 2 {
+		t.Fatalf("expected checkpoints, got: %d", len(o.snapshots))
+	}
+
+	// Restoring a snapshot into a fresh game and checkpointing straight away should get us the same snapshot.
+	s := o.snapshots[len(o.snapshots)/2]
+	g2 := getGame([]int{})
+	g2.reset()
+	turn, err := g2.restore(s)
+	if err != nil {
+		t.Fatalf("unexpected error restoring: %s", err)
+	}
+	g2.checkpoint(turn)
+	s2 := g2.LastCheckpoint()
+
+	if string(s2.State) != string(s.State) {
+		t.Errorf("restored state doesn't match, got: %s expected: %s", s2.State, s.State)
+	}
+	if string(s2.Rand) != string(s.Rand) {
+		t.Errorf("restored randomness doesn't match")
+	}
+	if len(s2.Events) != len(s.Events) || len(s2.Places) != len(s.Places) {
+		t.Errorf("restored events/places don't match, got: %d/%d expected: %d/%d", len(s2.Events), len(s2.Places), len(s.Events), len(s.Places))
+	}
+	for i, p := range g2.Players {
+		if p.ID != s.Players[i].ID || p.Position != i+1 {
+			t.Errorf("player %d not restored to position %d: %+v", p.ID, i+1, p)
+		}
+	}
+}
+
+func TestPlayerState(t *testing.T) {
+	g := getGame([]int{5, 3, 0, 2})
+	g.Board.Bid = 4
+	g.Board.Quantity = 3
+	g.Board.Bidder = g.Players[1]
+	g.Board.DiceShown[g.Players[1]] = []DiceVal{4}
+
+	p := g.Players[0]
+	if state := g.playerState(p); state != nil {
+		t.Errorf("Expected no state for a player who didn't opt in, got: %+v", state)
+	}
+
+	p.FullState = true
+	state := g.playerState(p)
+	if state == nil {
+		t.Fatalf("Expected state for a player who opted in")
+	}
+
+	if state.ID != p.ID || state.Bid != 4 || state.Quantity != 3 || state.Bidder != g.Players[1].ID {
+		t.Errorf("Expected bid of 3 4s by %d for %d, got: %+v", g.Players[1].ID, p.ID, state)
+	}
+	if !reflect.DeepEqual(state.Dice, g.Board.DiceHidden[p].Values) {
+		t.Errorf("Expected own dice %v, got: %v", g.Board.DiceHidden[p].Values, state.Dice)
+	}
+
+	expectedCounts := map[game.PlayerID]int{1: 5, 2: 4, 3: 0, 4: 2}
+	if !reflect.DeepEqual(state.DiceCounts, expectedCounts) {
+		t.Errorf("Expected dice counts %v, got: %v", expectedCounts, state.DiceCounts)
+	}
+	if !reflect.DeepEqual(state.DiceShown[2], []DiceVal{4}) {
+		t.Errorf("Expected player 2 to have shown a 4, got: %v", state.DiceShown[2])
+	}
+}
@@ -0,0 +1,176 @@
+package human
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/liarsdice"
+	"github.com/boardgamesai/games/liarsdice/ai/driver"
+)
+
+// Human lets a person play Liar's Dice from the terminal, typing bids like "bid 4 5s", optionally
+// followed by dice to show like "show 5 5", or "challenge".
+type Human struct {
+	t *game.Terminal
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetMove(state driver.State) liarsdice.Move {
+	h.t.ShowEvents(state.NewEvents, liarsdice.DecodeEvent)
+
+	total := 0
+	counts := []string{}
+	for _, p := range state.Players {
+		count := fmt.Sprintf("ID %d has %d", p.ID, state.DiceCounts[p.ID])
+		if shown := state.DiceShown[p.ID]; len(shown) > 0 {
+			count += fmt.Sprintf(" (showing %s)", shown)
+		}
+		counts = append(counts, count)
+		total += state.DiceCounts[p.ID]
+	}
+	h.t.Printf("\nDice left: %s\nYou're ID %d. Your dice: %s (%s is wild)\n", strings.Join(counts, ", "), state.ID, state.Dice, liarsdice.Star)
+	if state.Quantity > 0 {
+		h.t.Printf("Current bid: %d %ss by ID %d\n", state.Quantity, state.Bid, state.Bidder)
+	}
+
+	h.t.Printf("%s\n", summarizeMoves(state.Bid, state.Quantity, total))
+
+	move := liarsdice.Move{}
+	h.t.Ask("Your move (e.g. bid 4 5s, bid 4 5s show 5 5, or challenge): ", func(answer string) error {
+		m, err := parseMove(answer)
+		if err != nil {
+			return err
+		}
+		if err := liarsdice.IsValidBid(m, state.Bid, state.Quantity, total); err != nil {
+			return fmt.Errorf("can't make that move: %s", err)
+		}
+		if err := canShow(m.ShowDice, state.Dice); err != nil {
+			return err
+		}
+
+		move = m
+		return nil
+	})
+
+	return move
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, liarsdice.DecodeEvent)
+}
+
+// summarizeMoves lists the lowest legal bid on each face, rather than every one of them, and whether
+// there's a bid to challenge.
+func summarizeMoves(bid liarsdice.DiceVal, quantity, totalDice int) string {
+	lowest := []string{}
+	faces := map[liarsdice.DiceVal]bool{}
+	canChallenge := false
+	for _, m := range liarsdice.PossibleBids(bid, quantity, totalDice) {
+		switch {
+		case m.Challenge:
+			canChallenge = true
+		case !faces[m.Bid]:
+			faces[m.Bid] = true
+			lowest = append(lowest, fmt.Sprintf("%d %ss", m.Quantity, m.Bid))
+		}
+	}
+
+	switch {
+	case len(lowest) == 0:
+		return "No bid is higher, so challenge"
+	case canChallenge:
+		return fmt.Sprintf("Lowest bids: %s, or challenge", strings.Join(lowest, ", "))
+	default:
+		return fmt.Sprintf("Lowest bids: %s", strings.Join(lowest, ", "))
+	}
+}
+
+func parseMove(s string) (liarsdice.Move, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 1 && (fields[0] == "challenge" || fields[0] == "c") {
+		return liarsdice.Move{Challenge: true}, nil
+	}
+
+	if len(fields) > 0 && fields[0] == "bid" {
+		fields = fields[1:]
+	}
+	if len(fields) < 2 {
+		return liarsdice.Move{}, fmt.Errorf("need a quantity and a face, e.g. bid 4 5s")
+	}
+
+	m := liarsdice.Move{}
+	if _, err := fmt.Sscanf(fields[0], "%d", &m.Quantity); err != nil {
+		return m, fmt.Errorf("invalid quantity: %q", fields[0])
+	}
+	bid, err := parseDie(strings.TrimSuffix(fields[1], "s"))
+	if err != nil {
+		return m, err
+	}
+	m.Bid = bid
+
+	fields = fields[2:]
+	if len(fields) == 0 {
+		return m, nil
+	}
+	if fields[0] != "show" || len(fields) == 1 {
+		return m, fmt.Errorf("expected show and the dice to show, e.g. show 5 5")
+	}
+	for _, f := range fields[1:] {
+		d, err := parseDie(f)
+		if err != nil {
+			return m, err
+		}
+		m.ShowDice = append(m.ShowDice, d)
+	}
+
+	return m, nil
+}
+
+// parseDie takes a face from 1 to 6, where 1 is the wild star, which can also be typed as * or ★.
+func parseDie(s string) (liarsdice.DiceVal, error) {
+	if s == "*" || s == liarsdice.Star.String() {
+		return liarsdice.Star, nil
+	}
+
+	d := 0
+	if _, err := fmt.Sscanf(s, "%d", &d); err != nil || d < 1 || d > 6 || fmt.Sprintf("%d", d) != s {
+		return 0, fmt.Errorf("invalid die: %q", s)
+	}
+	return liarsdice.DiceVal(d), nil
+}
+
+// canShow checks the dice to show are among the player's, and that at least one is left to reroll.
+func canShow(show, dice []liarsdice.DiceVal) error {
+	if len(show) == 0 {
+		return nil
+	}
+	if len(show) >= len(dice) {
+		return fmt.Errorf("have to keep at least one die hidden")
+	}
+
+	left := append([]liarsdice.DiceVal{}, dice...)
+	for _, d := range show {
+		found := false
+		for i, d2 := range left {
+			if d == d2 {
+				left = append(left[:i], left[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("don't have a %s to show", d)
+		}
+	}
+
+	return nil
+}
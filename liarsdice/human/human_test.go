@@ -0,0 +1,73 @@
+package human
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boardgamesai/games/liarsdice"
+)
+
+func TestParseMove(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected liarsdice.Move
+		ok       bool
+	}{
+		{"challenge", liarsdice.Move{Challenge: true}, true},
+		{"C", liarsdice.Move{Challenge: true}, true},
+		{"bid 4 5s", liarsdice.Move{Quantity: 4, Bid: 5}, true},
+		{"4 5", liarsdice.Move{Quantity: 4, Bid: 5}, true},
+		{"bid 3 *s", liarsdice.Move{Quantity: 3, Bid: liarsdice.Star}, true},
+		{"bid 4 5s show 5 ★", liarsdice.Move{Quantity: 4, Bid: 5, ShowDice: []liarsdice.DiceVal{5, liarsdice.Star}}, true},
+		{"bid 4 7s", liarsdice.Move{}, false},
+		{"bid four 5s", liarsdice.Move{}, false},
+		{"bid 4", liarsdice.Move{}, false},
+		{"bid 4 5s show", liarsdice.Move{}, false},
+		{"bid 4 5s 5", liarsdice.Move{}, false},
+	}
+
+	for _, test := range tests {
+		m, err := parseMove(test.input)
+		if test.ok != (err == nil) || (test.ok && !reflect.DeepEqual(m, test.expected)) {
+			t.Errorf("%q: expected %+v ok %t, got: %+v err %v", test.input, test.expected, test.ok, m, err)
+		}
+	}
+}
+
+func TestCanShow(t *testing.T) {
+	dice := []liarsdice.DiceVal{2, 5, 5}
+	tests := []struct {
+		show []liarsdice.DiceVal
+		ok   bool
+	}{
+		{[]liarsdice.DiceVal{}, true},
+		{[]liarsdice.DiceVal{5, 5}, true},
+		{[]liarsdice.DiceVal{2, 2}, false},
+		{[]liarsdice.DiceVal{2, 5, 5}, false},
+		{[]liarsdice.DiceVal{6}, false},
+	}
+
+	for _, test := range tests {
+		if err := canShow(test.show, dice); test.ok != (err == nil) {
+			t.Errorf("%v: expected ok %t, got: %v", test.show, test.ok, err)
+		}
+	}
+}
+
+func TestSummarizeMoves(t *testing.T) {
+	tests := []struct {
+		bid      liarsdice.DiceVal
+		quantity int
+		expected string
+	}{
+		{0, 0, "Lowest bids: 1 ★s, 1 2s, 1 3s, 1 4s, 1 5s, 1 6s"},
+		{4, 3, "Lowest bids: 1 2s, 1 3s, 1 5s, 1 6s, 2 ★s, 4 4s, or challenge"},
+		{liarsdice.Star, 5, "No bid is higher, so challenge"},
+	}
+
+	for _, test := range tests {
+		if s := summarizeMoves(test.bid, test.quantity, 5); s != test.expected {
+			t.Errorf("%d %ss: expected %q, got: %q", test.quantity, test.bid, test.expected, s)
+		}
+	}
+}
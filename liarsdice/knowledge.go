@@ -0,0 +1,135 @@
+package liarsdice
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// Knowledge is what each player may know in Liar's Dice: their own rolls, and every bid and challenge.
+// Dice shown with a bid are part of the bid. How many dice everyone starts with comes in the setup
+// message rather than an event, so it's remembered from there for each player being checked.
+type Knowledge struct {
+	diceCounts map[game.PlayerID]map[game.PlayerID]int // The starting dice counts each player was sent
+}
+
+func (g *Game) Knowledge() game.Knowledge {
+	return &Knowledge{}
+}
+
+func (k *Knowledge) MayKnow(id game.PlayerID, e game.Event) bool {
+	if e.Type == EventTypeRoll {
+		roll := EventRoll{}
+		json.Unmarshal(e.Data, &roll)
+		return roll.ID == id
+	}
+
+	return true
+}
+
+// CheckMessage makes sure the hash and the dice in a player's state are of their own latest roll, and
+// that the dice shown for each player were shown with a bid this round. Everything else in a message,
+// like the bid and the dice counts, everyone knows, though it still has to match the hash.
+func (k *Knowledge) CheckMessage(id game.PlayerID, m game.Message, known []game.Event) []game.Leak {
+	if m.Type == "setup" {
+		setup := MessageSetup{}
+		if err := json.Unmarshal(m.Data, &setup); err != nil {
+			return []game.Leak{{Msg: err.Error()}}
+		}
+		if k.diceCounts == nil {
+			k.diceCounts = map[game.PlayerID]map[game.PlayerID]int{}
+		}
+		k.diceCounts[id] = setup.DiceCounts
+		return nil
+	}
+	if m.Type != "move" {
+		return nil
+	}
+
+	message := MessageMove{}
+	if err := json.Unmarshal(m.Data, &message); err != nil {
+		return []game.Leak{{Msg: err.Error()}}
+	}
+
+	// Everyone starts with the dice the setup said, and only challenges change how many they have.
+	// A challenge also ends the round, putting the shown dice back to be rolled again.
+	dice := []DiceVal{}
+	bid := DiceVal(0)
+	quantity := 0
+	diceCounts := map[game.PlayerID]int{}
+	for playerID, count := range k.diceCounts[id] {
+		diceCounts[playerID] = count
+	}
+	shown := map[game.PlayerID][]DiceVal{}
+	for _, e := range known {
+		switch e.Type {
+		case EventTypeRoll:
+			roll := EventRoll{}
+			json.Unmarshal(e.Data, &roll)
+			if roll.ID == id {
+				dice = roll.Dice
+			}
+		case EventTypeMove:
+			move := EventMove{}
+			json.Unmarshal(e.Data, &move)
+			bid = move.Bid
+			quantity = move.Quantity
+			shown[move.ID] = append(shown[move.ID], move.ShowDice...)
+		case EventTypeChallenge:
+			challenge := EventChallenge{}
+			json.Unmarshal(e.Data, &challenge)
+			for playerID, change := range challenge.DiceChange {
+				diceCounts[playerID] += change
+			}
+			bid = 0
+			quantity = 0
+			shown = map[game.PlayerID][]DiceVal{}
+		}
+	}
+
+	leaks := []game.Leak{}
+	if message.Hash != "" && message.Hash != Hash(dice, bid, quantity, diceCounts) {
+		leaks = append(leaks, game.Leak{
+			Field: "Hash",
+			Msg:   fmt.Sprintf("doesn't match the dice %s, bid %d x %s and dice counts %v", dice, quantity, bid, diceCounts),
+		})
+	}
+
+	if message.State == nil {
+		return leaks
+	}
+	for _, d := range unknownDice(message.State.Dice, dice) {
+		leaks = append(leaks, game.Leak{
+			Field: "State.Dice",
+			Msg:   fmt.Sprintf("%s isn't in the roll %s", d, dice),
+		})
+	}
+	for playerID, playerShown := range message.State.DiceShown {
+		for _, d := range unknownDice(playerShown, shown[playerID]) {
+			leaks = append(leaks, game.Leak{
+				Field: "State.DiceShown",
+				Msg:   fmt.Sprintf("%s hasn't been shown by P%d", d, playerID),
+			})
+		}
+	}
+	return leaks
+}
+
+// unknownDice returns the dice that aren't accounted for by known, each known die accounting for one.
+func unknownDice(dice, known []DiceVal) []DiceVal {
+	counts := map[DiceVal]int{}
+	for _, d := range known {
+		counts[d]++
+	}
+
+	unknown := []DiceVal{}
+	for _, d := range dice {
+		if counts[d] == 0 {
+			unknown = append(unknown, d)
+			continue
+		}
+		counts[d]--
+	}
+	return unknown
+}
@@ -0,0 +1,87 @@
+package liarsdice
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/boardgamesai/games/game"
+)
+
+func getKnowledgeEvent(t *testing.T, eventType string, data interface{}) game.Event {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Encoding event returned error: %s", err)
+	}
+	return game.Event{Type: eventType, Data: dataJSON}
+}
+
+func TestKnowledgeCheckState(t *testing.T) {
+	k := Knowledge{}
+	known := []game.Event{
+		getKnowledgeEvent(t, EventTypeRoll, EventRoll{ID: 2, Dice: []DiceVal{1, 1, 3}}),
+		getKnowledgeEvent(t, EventTypeMove, EventMove{ID: 1, Move: Move{Bid: 5, Quantity: 2, ShowDice: []DiceVal{5}}}),
+	}
+
+	tests := []struct {
+		dice   []DiceVal
+		shown  map[game.PlayerID][]DiceVal
+		leaked int
+	}{
+		{[]DiceVal{3, 1, 1}, map[game.PlayerID][]DiceVal{1: {5}, 2: {}}, 0},
+		{[]DiceVal{1, 1, 1}, map[game.PlayerID][]DiceVal{1: {5}}, 1},            // Only two 1s were rolled
+		{[]DiceVal{1, 1, 3, 4}, map[game.PlayerID][]DiceVal{1: {5}}, 1},         // The 4 is someone else's
+		{[]DiceVal{1, 1, 3}, map[game.PlayerID][]DiceVal{1: {5, 2}, 3: {6}}, 2}, // Hidden dice shown
+	}
+
+	for _, test := range tests {
+		data, _ := json.Marshal(MessageMove{State: &PlayerState{Dice: test.dice, DiceShown: test.shown}})
+
+		leaks := k.CheckMessage(2, game.Message{Type: "move", Data: data}, known)
+		if len(leaks) != test.leaked {
+			t.Errorf("Dice %v shown %v: expected %d leaks, got: %+v", test.dice, test.shown, test.leaked, leaks)
+		}
+	}
+
+	// Once the round's challenged, shown dice go back to being hidden
+	known = append(known, getKnowledgeEvent(t, EventTypeChallenge, EventChallenge{ID: 2}))
+	data, _ := json.Marshal(MessageMove{State: &PlayerState{Dice: []DiceVal{1, 1, 3}, DiceShown: map[game.PlayerID][]DiceVal{1: {5}}}})
+	if leaks := k.CheckMessage(2, game.Message{Type: "move", Data: data}, known); len(leaks) != 1 {
+		t.Errorf("Expected dice shown last round to leak, got: %+v", leaks)
+	}
+}
+
+func TestKnowledgeCheckHash(t *testing.T) {
+	k := Knowledge{}
+	setup, _ := json.Marshal(MessageSetup{ID: 2, DiceCounts: map[game.PlayerID]int{1: 2, 2: 3}})
+	if leaks := k.CheckMessage(2, game.Message{Type: "setup", Data: setup}, nil); len(leaks) != 0 {
+		t.Fatalf("Expected no leaks from the setup, got: %+v", leaks)
+	}
+
+	known := []game.Event{
+		getKnowledgeEvent(t, EventTypeRoll, EventRoll{ID: 2, Dice: []DiceVal{1, 1, 3}}),
+		getKnowledgeEvent(t, EventTypeMove, EventMove{ID: 1, Move: Move{Bid: 5, Quantity: 2}}),
+		getKnowledgeEvent(t, EventTypeChallenge, EventChallenge{ID: 2, DiceChange: map[game.PlayerID]int{2: -1}}),
+		getKnowledgeEvent(t, EventTypeRoll, EventRoll{ID: 2, Dice: []DiceVal{4, 6}}),
+		getKnowledgeEvent(t, EventTypeMove, EventMove{ID: 1, Move: Move{Bid: 6, Quantity: 1}}),
+	}
+
+	tests := []struct {
+		hash   string
+		leaked int
+	}{
+		{"", 0},
+		{Hash([]DiceVal{6, 4}, 6, 1, map[game.PlayerID]int{1: 2, 2: 2}), 0},
+		{Hash([]DiceVal{1, 1, 3}, 6, 1, map[game.PlayerID]int{1: 2, 2: 2}), 1},    // Last round's roll
+		{Hash([]DiceVal{4, 6}, 6, 1, map[game.PlayerID]int{1: 2, 2: 3}), 1},       // Before the challenge
+		{Hash([]DiceVal{4, 6, 2, 2}, 6, 1, map[game.PlayerID]int{1: 2, 2: 2}), 1}, // Someone else's dice too
+	}
+
+	for _, test := range tests {
+		data, _ := json.Marshal(MessageMove{Hash: test.hash})
+
+		leaks := k.CheckMessage(2, game.Message{Type: "move", Data: data}, known)
+		if len(leaks) != test.leaked {
+			t.Errorf("Hash %q: expected %d leaks, got: %+v", test.hash, test.leaked, leaks)
+		}
+	}
+}
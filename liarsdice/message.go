@@ -5,11 +5,29 @@ import (
 )
 
 type MessageSetup struct {
-	ID       game.PlayerID
-	Position int
-	Players  []*Player
+	ID         game.PlayerID
+	Position   int
+	Players    []*Player
+	DiceCounts map[game.PlayerID]int // How many dice each player starts with
 }
 
 type MessageMove struct {
-	NewEvents []game.Event
+	NewEvents  []game.Event
+	Hash       string       // Of the player's dice, the bid and the dice counts once NewEvents are applied
+	LegalMoves []Move       // Only for players who opted in with LegalMoves, null for everyone else
+	State      *PlayerState `json:",omitempty"` // Only for players who opted in with FullState
+}
+
+// PlayerState is everything the player can see of the game when it's their turn, so a client doesn't
+// have to keep track of it from the events. A Quantity of 0 means no one has bid yet this round.
+type PlayerState struct {
+	ID         game.PlayerID
+	Position   int
+	Players    []*Player
+	Dice       []DiceVal // This player's dice that haven't been shown
+	Bid        DiceVal
+	Quantity   int
+	Bidder     game.PlayerID               `json:",omitempty"`
+	DiceCounts map[game.PlayerID]int       // How many dice each player has left, shown or not
+	DiceShown  map[game.PlayerID][]DiceVal // Dice each player has shown this round
 }
@@ -0,0 +1,85 @@
+package liarsdice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// SetPosition has the game start with fixed dice rather than a roll of five each, or goes back to
+// rolling if position is "". It's each seat's dice, 1 being a star, separated by "/", optionally
+// followed by the seat (1-4) of whoever bids first, e.g. "11435/2266/5/3 2". Every roll after that
+// is random as usual.
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the starting dice in seat order, or nil if they're to be rolled as usual,
+// along with the index into Players of whoever bids first.
+func (g *Game) startPosition() ([][]DiceVal, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	dice, seat, _ := parsePosition(g.StartPosition())
+	return dice, seat - 1
+}
+
+// startDiceCounts returns how many dice each player starts with.
+func (g *Game) startDiceCounts() map[game.PlayerID]int {
+	counts := map[game.PlayerID]int{}
+	startDice, _ := g.startPosition()
+	for i, p := range g.Players {
+		counts[p.ID] = 5
+		if startDice != nil {
+			counts[p.ID] = len(startDice[i])
+		}
+	}
+	return counts
+}
+
+func parsePosition(position string) ([][]DiceVal, int, error) {
+	fields := strings.Fields(position)
+	if len(fields) < 1 || len(fields) > 2 {
+		return nil, 0, game.PositionError(position, "expected dice, optionally followed by who bids first")
+	}
+
+	seat := 1
+	if len(fields) == 2 {
+		if _, err := fmt.Sscanf(fields[1], "%d", &seat); err != nil || seat < 1 || seat > 4 {
+			return nil, 0, game.PositionError(position, fmt.Sprintf("invalid seat %q, expected 1 to 4", fields[1]))
+		}
+	}
+
+	diceStrs := strings.Split(fields[0], "/")
+	if len(diceStrs) != 4 {
+		return nil, 0, game.PositionError(position, fmt.Sprintf("expected dice for 4 seats, got %d", len(diceStrs)))
+	}
+
+	allDice := [][]DiceVal{}
+	for i, diceStr := range diceStrs {
+		if len(diceStr) == 0 {
+			return nil, 0, game.PositionError(position, fmt.Sprintf("seat %d needs at least one die", i+1))
+		}
+
+		dice := []DiceVal{}
+		for _, d := range diceStr {
+			if d < '1' || d > '6' {
+				return nil, 0, game.PositionError(position, fmt.Sprintf("invalid die %q", d))
+			}
+			dice = append(dice, DiceVal(d-'0'))
+		}
+		allDice = append(allDice, dice)
+	}
+
+	return allDice, seat, nil
+}
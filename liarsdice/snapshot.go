@@ -0,0 +1,76 @@
+package liarsdice
+
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/game/elements/dice"
+)
+
+// snapshotState is what we need on top of the game package's snapshot to pick up where we left off.
+// Players are referred to by their index into Players, since the board keys on pointers.
+type snapshotState struct {
+	Bid        DiceVal
+	Quantity   int
+	Bidder     int // -1 if there's no bid yet this round
+	DiceHidden [][]DiceVal
+	DiceShown  [][]DiceVal
+	Turn       int
+}
+
+// Resume picks up a game from a snapshot, such as one from LastCheckpoint. The players are
+// relaunched and sent the whole event history with their next move request.
+func (g *Game) Resume(ctx context.Context, s *game.Snapshot) error {
+	return g.Finish(ctx, g.play(ctx, s))
+}
+
+func (g *Game) checkpoint(turn int) {
+	state := snapshotState{
+		Bid:        g.Board.Bid,
+		Quantity:   g.Board.Quantity,
+		Bidder:     -1,
+		DiceHidden: [][]DiceVal{},
+		DiceShown:  [][]DiceVal{},
+		Turn:       turn,
+	}
+
+	for i, p := range g.Players {
+		if p == g.Board.Bidder {
+			state.Bidder = i
+		}
+		state.DiceHidden = append(state.DiceHidden, g.Board.DiceHidden[p].Values)
+		state.DiceShown = append(state.DiceShown, g.Board.DiceShown[p])
+	}
+
+	g.Checkpoint(state)
+}
+
+func (g *Game) restore(s *game.Snapshot) (int, error) {
+	state := snapshotState{}
+	if err := g.Restore(s, &state); err != nil {
+		return 0, err
+	}
+
+	g.assignSeats()
+
+	// We build the board by hand, since newBoard would roll and throw off the restored randomness.
+	b := &Board{
+		Bid:        state.Bid,
+		Quantity:   state.Quantity,
+		DiceHidden: map[*Player]*Dice{},
+		DiceShown:  map[*Player][]DiceVal{},
+		players:    g.Players,
+		rng:        g.Rand(),
+	}
+	if state.Bidder >= 0 {
+		b.Bidder = g.Players[state.Bidder]
+	}
+	for i, p := range g.Players {
+		b.DiceHidden[p] = &Dice{dice.New(0, diceVals)}
+		b.DiceHidden[p].Values = append([]DiceVal{}, state.DiceHidden[i]...)
+		b.DiceShown[p] = append([]DiceVal{}, state.DiceShown[i]...)
+	}
+	g.Board = b
+
+	return state.Turn, nil
+}
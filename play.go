@@ -1,21 +1,54 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/game/factory"
+	"github.com/boardgamesai/games/util"
 )
 
 func main() {
 	numGamesFlag := flag.Int("n", 1, "number of games to play")
 	randomFlag := flag.Bool("random", false, "should we play a random game")
 	rawEventsFlag := flag.Bool("raw", false, "display raw or formatted event log")
+	viewFlag := flag.String("view", "full", "whose view of the event log to show: full, public, or a player ID")
+	revealFlag := flag.String("reveal", string(game.RevealPlayers), "who sees hidden events once the game is over: players, all or none")
+	revealedFlag := flag.Bool("revealed", false, "with -view public or a player ID, also show what was revealed to them once the game was over")
+	legalMovesFlag := flag.Bool("legalmoves", false, "send each player the legal moves along with every move request")
+	fullStateFlag := flag.Bool("fullstate", false, "send each player the whole state of the game along with every move request")
 	printBoardFlag := flag.Bool("print", false, "print the board at the end of the game")
+	dqRankFlag := flag.String("dqrank", string(game.DQRankStandings), "how to rank the other players after a DQ: standings or shared")
+	substituteFlag := flag.Bool("substitute", false, "replace a DQ'd player with a fallback AI and finish the game")
+	checkpointFlag := flag.String("checkpoint", "", "save a snapshot to this file at every checkpoint, to pick up later with -resume")
+	resumeFlag := flag.String("resume", "", "resume a single game from a snapshot file saved with -checkpoint")
+	positionFlag := flag.String("position", "", "start the game from this position instead of the usual setup (see the game's README for the format)")
+	openingsFlag := flag.String("openings", "", "play each opening in a suite twice, swapping seats: bundled, random, or a file of positions")
+	pliesFlag := flag.Int("plies", 4, "number of random moves in each opening with -openings random")
+	seatingFlag := flag.String("seating", string(game.SeatingRandom), "where players sit each game: random, fixed (in the order given) or rotated (one seat along each game)")
+	seedFlag := flag.Uint64("seed", 0, "random seed for deals and rolls, so they can be played again (0 picks a new one each game)")
+	duplicateFlag := flag.Bool("duplicate", false, "play -n deals once with the players in each seat, so they all get the same cards or dice")
+	maxPliesFlag := flag.Int("maxplies", 0, "stop each game after this many moves and decide it by the game's tie-break rule (0 means no limit)")
+	adjudicateFlag := flag.Bool("adjudicate", false, "decide a game as soon as its outcome is settled, e.g. Amazons once the queens are walled off")
+	ponderFlag := flag.Bool("ponder", false, "tell every player about each move as it happens, so AIs that ponder can think on their opponents' time")
+	warmUpFlag := flag.Duration("warmup", 0, "time each player gets to warm up before the first move, e.g. 1m (0 means no warm-up)")
+	logCapFlag := flag.Int("logcap", game.DefaultLogCap, "bytes of each player's logged output to keep, dropping the oldest lines past that")
+	tokenFlag := flag.String("token", "", "shared secret remote players have to send when they connect")
+	streamFlag := flag.String("stream", "", "stream the games live to browsers at this address, e.g. localhost:8080")
+	replayFlag := flag.String("replay", "", "save each game as a replay page to this file, which plays back offline in a browser")
+	timeoutFlag := flag.Duration("timeout", 0, "abort the match after this much wall-clock time, e.g. 5m (0 means no limit)")
 	flag.Parse()
 
 	numGames := *numGamesFlag
@@ -25,6 +58,10 @@ func main() {
 		log.Fatalf("Invalid number of games: %d\n", numGames)
 	}
 
+	if *logCapFlag < 1 {
+		log.Fatalf("Invalid log cap: %d\n", *logCapFlag)
+	}
+
 	args := flag.Args()
 	if len(args) == 0 {
 		log.Fatalf("Usage: %s", usageNoGame())
@@ -36,6 +73,38 @@ func main() {
 		log.Fatalf("%s", err)
 	}
 
+	dqRanking := game.DQRanking(*dqRankFlag)
+	if dqRanking != game.DQRankStandings && dqRanking != game.DQRankShared {
+		log.Fatalf("Invalid DQ ranking: %s\n", dqRanking)
+	}
+	seating := game.Seating(*seatingFlag)
+	if seating != game.SeatingRandom && seating != game.SeatingFixed && seating != game.SeatingRotated {
+		log.Fatalf("Invalid seating: %s\n", seating)
+	}
+	reveal := game.Reveal(*revealFlag)
+	if reveal != game.RevealPlayers && reveal != game.RevealAll && reveal != game.RevealNone {
+		log.Fatalf("Invalid reveal: %s\n", reveal)
+	}
+	g.SetOptions(game.Options{
+		DQRanking:  dqRanking,
+		Substitute: *substituteFlag,
+		Seating:    seating,
+		Reveal:     reveal,
+		MaxPlies:   *maxPliesFlag,
+		Adjudicate: *adjudicateFlag,
+		Ponder:     *ponderFlag,
+		WarmUp:     *warmUpFlag,
+	})
+
+	view, err := parseView(*viewFlag, *revealedFlag)
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	if err := g.SetPosition(*positionFlag); err != nil {
+		log.Fatalf("%s", err)
+	}
+
 	numPlayers := game.Data[gameName].NumPlayers
 	filenames := []string{}
 	if playRandom {
@@ -56,21 +125,131 @@ func main() {
 	}
 
 	players := g.GetPlayers()
+	terminal := game.NewTerminal(os.Stdin, os.Stdout)
 	for i, filename := range filenames {
 		players[i].ID = game.PlayerID(i + 1)
+
+		// A person playing from the terminal instead of an AI
+		if filename == "human" {
+			players[i].Name = "human"
+			if players[i].Runnable, err = factory.NewHuman(gameName, terminal); err != nil {
+				log.Fatalf("%s", err)
+			}
+			continue
+		}
+
+		// An AI running somewhere else, connecting to us or waiting for us to connect
+		if game.IsRemote(filename) {
+			addr, err := game.ParseRemote(filename)
+			if err != nil {
+				log.Fatalf("%s", err)
+			}
+			if *tokenFlag == "" {
+				log.Fatalf("remote player %s needs a -token to authenticate with", addr)
+			}
+
+			players[i].Name = addr.String()
+			players[i].Runnable = game.NewRemotePlayer(addr, *tokenFlag)
+			players[i].LegalMoves = *legalMovesFlag
+			players[i].FullState = *fullStateFlag
+			continue
+		}
+
 		players[i].Name = game.FileNameToPlayerName(filename)
-		players[i].Runnable = game.NewRunnablePlayer(string(gameName), filename)
+		runnable := game.NewRunnablePlayer(string(gameName), filename)
+		if err := runnable.SetLogCap(*logCapFlag); err != nil {
+			log.Fatalf("%s", err)
+		}
+		players[i].Runnable = runnable
+		players[i].LegalMoves = *legalMovesFlag
+		players[i].FullState = *fullStateFlag
+	}
+
+	// Ctrl-C aborts the match cleanly, shutting down the player processes.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *timeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeoutFlag)
+		defer cancel()
+	}
+
+	if *streamFlag != "" {
+		stream, err := startStream(gameName, *streamFlag)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+		defer stream.Close()
+		g.AddObserver(stream)
+	}
+
+	if *checkpointFlag != "" {
+		g.AddObserver(checkpointWriter{path: *checkpointFlag})
+	}
+
+	if *replayFlag != "" {
+		// Number the files if there's going to be more than one game
+		numbered := numGames > 1 || *openingsFlag != "" || *duplicateFlag
+		g.AddObserver(&replayWriter{g: g, path: *replayFlag, numbered: numbered})
+	}
+
+	if *duplicateFlag {
+		if *openingsFlag != "" || *resumeFlag != "" {
+			log.Fatalf("Can't use -duplicate with -openings or -resume\n")
+		}
+
+		// Seeds follow on from -seed if it's given, so a run can be repeated
+		seeds := []uint64{}
+		for i := 0; i < numGames; i++ {
+			if *seedFlag != 0 {
+				seeds = append(seeds, *seedFlag+uint64(i))
+			} else {
+				seeds = append(seeds, util.RandSeed())
+			}
+		}
+		playDuplicate(ctx, g, seeds, gameName)
+		return
+	}
+	g.SetSeed(*seedFlag)
+
+	if *openingsFlag != "" {
+		if *positionFlag != "" || *resumeFlag != "" {
+			log.Fatalf("Can't use -openings with -position or -resume\n")
+		}
+
+		openings, err := loadOpenings(g, *openingsFlag, numGames, *pliesFlag)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+		playOpenings(ctx, g, openings)
+		return
+	}
+
+	var snapshot *game.Snapshot
+	if *resumeFlag != "" {
+		if numGames != 1 {
+			log.Fatalf("Can only resume a single game\n")
+		}
+		if snapshot, err = readSnapshot(*resumeFlag); err != nil {
+			log.Fatalf("%s", err)
+		}
 	}
 
 	if numGames == 1 {
-		playOneGame(g, gameName, *rawEventsFlag, *printBoardFlag)
+		playOneGame(ctx, g, snapshot, gameName, view, *rawEventsFlag, *printBoardFlag)
 	} else {
-		playMultipleGames(g, numGames)
+		playMultipleGames(ctx, g, numGames)
 	}
 }
 
-func playOneGame(g game.Playable, gameName game.Name, showRawEvents, printBoard bool) {
-	gameErr := g.Play()
+func playOneGame(ctx context.Context, g game.Playable, snapshot *game.Snapshot, gameName game.Name, view game.View, showRawEvents, printBoard bool) {
+	var gameErr error
+	if snapshot == nil {
+		gameErr = g.PlayContext(ctx)
+	} else {
+		gameErr = g.Resume(ctx, snapshot)
+	}
 
 	fmt.Printf("Ordered players:\n")
 	for _, player := range g.GetPlayers() {
@@ -80,11 +259,11 @@ func playOneGame(g game.Playable, gameName game.Name, showRawEvents, printBoard
 	fmt.Println()
 
 	if showRawEvents {
-		for i, event := range g.RawEvents() {
+		for i, event := range g.RawEventsFor(view) {
 			fmt.Printf("%d. %s\n", i+1, event)
 		}
 	} else {
-		for i, event := range g.Events() {
+		for i, event := range g.EventsFor(view) {
 			fmt.Printf("%d. %s\n", i+1, event)
 		}
 	}
@@ -103,6 +282,15 @@ func playOneGame(g game.Playable, gameName game.Name, showRawEvents, printBoard
 		fmt.Println()
 	}
 
+	switch g.Termination() {
+	case game.TerminationResign:
+		fmt.Println("(ended by resignation)")
+	case game.TerminationDraw:
+		fmt.Println("(drawn by agreement)")
+	case game.TerminationAdjudicated:
+		fmt.Println("(adjudicated)")
+	}
+
 	if gameErr != nil {
 		fmt.Printf("*** game ended with error: %s\n", gameErr)
 	}
@@ -114,7 +302,7 @@ func playOneGame(g game.Playable, gameName game.Name, showRawEvents, printBoard
 	printLoggedOutput(g)
 }
 
-func playMultipleGames(g game.Playable, numGames int) {
+func playMultipleGames(ctx context.Context, g game.Playable, numGames int) {
 	// Grab a copy of the game's players in the original order. The game will shuffle them,
 	// but we want to know the original order for reporting purposes.
 	players := make([]*game.Player, len(g.GetPlayers()))
@@ -128,7 +316,11 @@ func playMultipleGames(g game.Playable, numGames int) {
 	for i := 1; i <= numGames; i++ {
 		fmt.Printf("playing game %d...\n", i)
 
-		err := g.Play()
+		err := g.PlayContext(ctx)
+		if g.Termination() == game.TerminationAborted {
+			fmt.Printf("game %d aborted, stopping: %s\n", i, err)
+			break
+		}
 		if err != nil {
 			fmt.Printf("game %d ended with error: %s\n", i, err)
 			continue
@@ -143,6 +335,188 @@ func playMultipleGames(g game.Playable, numGames int) {
 	printSummaryTotals(players, outcomes)
 }
 
+func playOpenings(ctx context.Context, g game.Playable, openings []game.Opening) {
+	players := make([]*game.Player, len(g.GetPlayers()))
+	copy(players, g.GetPlayers())
+
+	fmt.Printf("playing %d openings, twice each...\n", len(openings))
+	results, err := game.PlayOpenings(ctx, g, openings)
+	if err != nil {
+		fmt.Printf("stopped early: %s\n", err)
+	}
+
+	fmt.Println()
+	printOpeningResults(players, results)
+}
+
+func playDuplicate(ctx context.Context, g game.Playable, seeds []uint64, gameName game.Name) {
+	players := make([]*game.Player, len(g.GetPlayers()))
+	copy(players, g.GetPlayers())
+
+	fmt.Printf("playing %d deals, %d times each...\n", len(seeds), len(players))
+	results, err := game.PlayDuplicate(ctx, g, seeds)
+	if err != nil {
+		fmt.Printf("stopped early: %s\n", err)
+	}
+
+	fmt.Println()
+	printDuplicateResults(players, results, game.Data[gameName].HasScore)
+}
+
+// loadOpenings gets a suite of openings from the game's bundled ones, random playouts, or a file.
+func loadOpenings(g game.Playable, source string, numOpenings, plies int) ([]game.Opening, error) {
+	suiter, ok := g.(game.OpeningSuiter)
+
+	switch source {
+	case "bundled", "random":
+		if !ok {
+			return nil, fmt.Errorf("this game doesn't have %s openings, but can play openings from a file", source)
+		}
+		if source == "bundled" {
+			return suiter.Openings(), nil
+		}
+		return game.RandomOpenings(suiter, util.NewRand(util.RandSeed()), numOpenings, plies)
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return game.ReadOpenings(f)
+}
+
+// checkpointWriter saves every checkpoint to a file as the game goes, so it survives a crash.
+type checkpointWriter struct {
+	game.NopObserver
+	path string
+}
+
+func (w checkpointWriter) OnCheckpoint(s *game.Snapshot) {
+	snapshotJSON, err := json.Marshal(s)
+	if err != nil {
+		log.Printf("couldn't encode checkpoint: %s\n", err)
+		return
+	}
+
+	// Write to the side and move it over, so we never leave a half-written file.
+	tmpPath := w.path + ".tmp"
+	if err := os.WriteFile(tmpPath, snapshotJSON, 0600); err != nil {
+		log.Printf("couldn't write checkpoint: %s\n", err)
+		return
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		log.Printf("couldn't write checkpoint: %s\n", err)
+	}
+}
+
+// replayWriter saves each game as a replay page once it's over, numbering the files if there are several.
+type replayWriter struct {
+	game.NopObserver
+	g        game.Playable
+	path     string
+	numbered bool
+
+	name  game.Name
+	games int
+}
+
+func (w *replayWriter) OnStart(name game.Name, players []*game.Player) {
+	w.name = name
+}
+
+func (w *replayWriter) OnEnd(places []game.Place, termination game.Termination, err error) {
+	w.games++
+	path := w.path
+	if w.numbered {
+		ext := filepath.Ext(path)
+		path = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), w.games, ext)
+	}
+
+	f, fileErr := os.Create(path)
+	if fileErr != nil {
+		log.Printf("couldn't write replay: %s\n", fileErr)
+		return
+	}
+	defer f.Close()
+
+	if fileErr = game.NewReplay(w.name, w.g, err).WriteHTML(f); fileErr != nil {
+		log.Printf("couldn't write replay: %s\n", fileErr)
+	}
+}
+
+// streamServer streams the games live to anyone watching in a browser.
+type streamServer struct {
+	*game.Stream
+	server *http.Server
+}
+
+func startStream(gameName game.Name, addr string) (*streamServer, error) {
+	decode, err := factory.EventDecoder(gameName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listen up front, so a bad address fails before any games are played
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &streamServer{Stream: game.NewStream(decode)}
+	s.server = &http.Server{Handler: s.Stream}
+	go s.server.Serve(listener)
+
+	fmt.Printf("streaming live at http://%s/\n", listener.Addr())
+	return s, nil
+}
+
+// Close tells everyone watching that we're done, and gives them a moment to hear it.
+func (s *streamServer) Close() {
+	s.Stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	s.server.Shutdown(ctx)
+}
+
+func readSnapshot(path string) (*game.Snapshot, error) {
+	snapshotJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := game.Snapshot{}
+	if err := json.Unmarshal(snapshotJSON, &s); err != nil {
+		return nil, fmt.Errorf("couldn't read snapshot %s: %s", path, err)
+	}
+
+	return &s, nil
+}
+
+// parseView turns -view into a game.View, which is full, public or a player ID, along with what was
+// revealed once the game was over if -revealed is given.
+func parseView(s string, revealed bool) (game.View, error) {
+	id := game.ShowAll
+	switch s {
+	case "full":
+		return game.ViewFull, nil
+	case "public":
+	default:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || n == 0 {
+			return game.View{}, fmt.Errorf("invalid view: %s", s)
+		}
+		id = game.PlayerID(n)
+	}
+
+	if revealed {
+		return game.ViewRevealed(id), nil
+	}
+	return game.ViewPlayer(id), nil
+}
+
 func usage(gameName game.Name, numPlayers int) string {
 	players := make([]string, numPlayers)
 	for i := 1; i <= numPlayers; i++ {
@@ -153,7 +527,7 @@ func usage(gameName game.Name, numPlayers int) string {
 }
 
 func usageNoGame() string {
-	return "go run play.go [-n numGames] <game> <player1> <player2> ..."
+	return "go run play.go [-n numGames] <game> <player1> <player2> ... (a player can be human, or remote, e.g. listen:tcp::9000)"
 }
 
 func printLoggedOutput(g game.Playable) {
@@ -201,3 +575,87 @@ func printSummaryTotals(players []*game.Player, outcomes map[game.PlayerID]map[i
 		fmt.Printf(nameFormat+": %s\n", player.Name, strings.Join(totals, ", "))
 	}
 }
+
+func printOpeningResults(players []*game.Player, results []game.OpeningResult) {
+	maxlen := 0
+	for _, result := range results {
+		if len(result.Name) > maxlen {
+			maxlen = len(result.Name)
+		}
+	}
+	nameFormat := fmt.Sprintf("%%%ds", maxlen)
+
+	fmt.Println("Results by opening:")
+
+	totals := map[game.PlayerID]float64{}
+	won := map[game.PlayerID]int{}
+	for _, result := range results {
+		scores := []string{}
+		for _, player := range players {
+			score := result.Score(player.ID)
+			totals[player.ID] += score
+			if score > 1 {
+				won[player.ID]++
+			}
+			scores = append(scores, fmt.Sprintf("%s %.1f", player.Name, score))
+		}
+
+		notes := ""
+		for _, g := range result.Games {
+			if g.Err != nil {
+				notes += fmt.Sprintf(" (%s)", g.Err)
+			}
+		}
+
+		fmt.Printf(nameFormat+": %s%s\n", result.Name, strings.Join(scores, ", "), notes)
+	}
+
+	fmt.Printf("\nTotals over %d openings:\n", len(results))
+	for _, player := range players {
+		fmt.Printf("%s: %.1f points, won %d openings\n", player.Name, totals[player.ID], won[player.ID])
+	}
+}
+
+func printDuplicateResults(players []*game.Player, results []game.DuplicateResult, hasScore bool) {
+	fmt.Println("Results by deal (total ranks, lower is better):")
+
+	ranks := map[game.PlayerID]int{}
+	scores := map[game.PlayerID]int{}
+	numGames := 0
+	for _, result := range results {
+		totals := []string{}
+		for _, player := range players {
+			ranks[player.ID] += result.Ranks(player.ID)
+			scores[player.ID] += result.Score(player.ID)
+
+			total := fmt.Sprintf("%s %d", player.Name, result.Ranks(player.ID))
+			if hasScore {
+				total += fmt.Sprintf(" (score %d)", result.Score(player.ID))
+			}
+			totals = append(totals, total)
+		}
+		numGames += len(result.Games)
+
+		notes := ""
+		for _, g := range result.Games {
+			if g.Err != nil {
+				notes += fmt.Sprintf(" (%s)", g.Err)
+			}
+		}
+
+		fmt.Printf("seed %d: %s%s\n", result.Seed, strings.Join(totals, ", "), notes)
+	}
+
+	if numGames == 0 {
+		return
+	}
+
+	fmt.Printf("\nTotals over %d deals, %d games:\n", len(results), numGames)
+	for _, player := range players {
+		fmt.Printf("%s: average rank %.2f", player.Name, float64(ranks[player.ID])/float64(numGames))
+		if hasScore {
+			fmt.Printf(", total score %d", scores[player.ID])
+		}
+		fmt.Println()
+	}
+}
@@ -1,6 +1,7 @@
 package driver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -43,15 +44,31 @@ func (d *AIDriver) Run() {
 			response, err = d.handleSetup(message.Data)
 		case "move":
 			response, err = d.handleMove(message.Data)
+		case "warmup":
+			response, err = d.handleWarmUp(message.Data)
+		case "ponder":
+			response, err = d.handlePonder(message.Data)
+		case "gameover":
+			response, err = d.handleGameOver(message.Data)
 		default:
 			log.Fatalf("Unknown message type: %s", message.Type)
 		}
 
+		if dqErr, ok := err.(*game.DQError); ok {
+			// Let the engine know why rather than crashing, e.g. on a desync
+			d.PrintErrorResponse(dqErr)
+			continue
+		}
 		if err != nil {
 			log.Fatalf("Error handling message: %+v err: %s", message, err)
 		}
 
 		d.PrintResponse(response)
+
+		// Nothing comes after the game over message, and we're about to be shut down
+		if message.Type == "gameover" {
+			return
+		}
 	}
 }
 
@@ -66,6 +83,9 @@ func (d *AIDriver) handleSetup(message []byte) ([]byte, error) {
 	d.state.ID = setupMessage.ID
 	d.state.Order = setupMessage.Order
 	d.state.Opponent = setupMessage.Opponent
+	if setupMessage.Board != nil {
+		d.state.Board = setupMessage.Board
+	}
 
 	d.discs[d.state.ID] = d.state.Disc
 	d.discs[d.state.Opponent.ID] = d.state.Opponent.Disc
@@ -80,20 +100,83 @@ func (d *AIDriver) handleMove(message []byte) ([]byte, error) {
 		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
 	}
 
-	// Apply all our move events to keep the board up to date
-	for _, event := range moveMessage.NewEvents {
-		if event.Type == reversi.EventTypeMove {
-			e := reversi.EventMove{}
-			json.Unmarshal(event.Data, &e)
-			d.state.Board.ApplyMove(d.discs[e.ID], e.Move)
-		}
+	d.processNewEvents(moveMessage.NewEvents)
+	if dqErr := d.CheckHash(d.state.ID, moveMessage.Hash, d.state.Board.Hash()); dqErr != nil {
+		return []byte{}, dqErr
 	}
-	d.state.AddEvents(moveMessage.NewEvents)
 
 	move := d.ai.GetMove(*d.state)
-	moveJSON, err := json.Marshal(&move)
+	if ai, ok := d.ai.(annotatingAI); ok {
+		d.Annotate(ai.Annotation())
+	}
+
+	response := struct {
+		reversi.Move
+		game.Concession
+	}{Move: move}
+	if ai, ok := d.ai.(concedingAI); ok {
+		response.Concession = ai.Concession()
+	}
+	moveJSON, err := json.Marshal(&response)
 	if err != nil {
 		return []byte{}, fmt.Errorf("JSON encode failed: %+v err: %s", move, err)
 	}
 	return moveJSON, nil
 }
+
+func (d *AIDriver) handleWarmUp(message []byte) ([]byte, error) {
+	warmUpMessage := game.MessageWarmUp{}
+	if err := json.Unmarshal(message, &warmUpMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	if ai, ok := d.ai.(warmingUpAI); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), warmUpMessage.AITimeLimit())
+		defer cancel()
+		ai.WarmUp(ctx, *d.state)
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *AIDriver) handlePonder(message []byte) ([]byte, error) {
+	ponderMessage := game.MessagePonder{}
+	if err := json.Unmarshal(message, &ponderMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	d.processNewEvents(ponderMessage.NewEvents)
+
+	if ai, ok := d.ai.(ponderingAI); ok {
+		state := *d.state
+		d.StartPondering(func(ctx context.Context) {
+			ai.Ponder(ctx, state)
+		})
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *AIDriver) handleGameOver(message []byte) ([]byte, error) {
+	gameOverMessage := game.MessageGameOver{}
+	if err := json.Unmarshal(message, &gameOverMessage); err != nil {
+		return []byte{}, fmt.Errorf("JSON decode failed: %s err: %s", message, err)
+	}
+
+	d.processNewEvents(gameOverMessage.NewEvents)
+
+	if ai, ok := d.ai.(gameOverAI); ok {
+		ai.GameOver(*d.state, gameOverMessage)
+	}
+	return d.OkJSON(), nil
+}
+
+func (d *AIDriver) processNewEvents(events []game.Event) {
+	// Apply all our move events to keep the board up to date
+	for _, event := range events {
+		if event.Type == reversi.EventTypeMove {
+			e := reversi.EventMove{}
+			json.Unmarshal(event.Data, &e)
+			d.state.Board.ApplyMove(d.discs[e.ID], e.Move)
+		}
+	}
+	d.state.AddEvents(events)
+}
@@ -1,6 +1,13 @@
 package reversi
 
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
 type AIComms interface {
-	Setup(p *Player, other *Player) error
-	GetMove(p *Player) (Move, error)
+	Setup(ctx context.Context, p *Player, other *Player, start *Board) error
+	GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error)
+	GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error
 }
@@ -2,6 +2,8 @@ package reversi
 
 import (
 	"strings"
+
+	"github.com/boardgamesai/games/game"
 )
 
 type Disc string
@@ -228,3 +230,8 @@ func opposite(d Disc) Disc {
 	}
 	return White
 }
+
+// Hash fingerprints the board, so a driver can check its copy against ours.
+func (b *Board) Hash() string {
+	return game.Hash(b)
+}
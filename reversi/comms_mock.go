@@ -1,15 +1,25 @@
 package reversi
 
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
 type CommsMock struct {
 	moves map[int][]Move
 }
 
-func (c *CommsMock) Setup(p *Player, other *Player) error {
+func (c *CommsMock) Setup(ctx context.Context, p *Player, other *Player, start *Board) error {
 	return nil
 }
 
-func (c *CommsMock) GetMove(p *Player) (Move, error) {
+func (c *CommsMock) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error) {
 	m := c.moves[p.Order][0]
 	c.moves[p.Order] = c.moves[p.Order][1:]
-	return m, nil
+	return m, game.Concession{}, nil
+}
+
+func (c *CommsMock) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return nil
 }
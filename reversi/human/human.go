@@ -0,0 +1,76 @@
+package human
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/reversi"
+	"github.com/boardgamesai/games/reversi/ai/driver"
+)
+
+// Human lets a person play Reversi from the terminal, typing squares like "d3".
+type Human struct {
+	t          *game.Terminal
+	concession game.Concession // Typed along with, or instead of, the last move
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetMove(state driver.State) reversi.Move {
+	h.t.ShowEvents(state.NewEvents, reversi.DecodeEvent)
+	score := state.Board.Score()
+	h.t.Printf("\n%s\nYou're %s, %d discs to %d. Legal moves:", render(state.Board), state.Disc, score[state.Disc], score[opponent(state.Disc)])
+	for _, m := range state.Board.PossibleMoves(state.Disc) {
+		h.t.Printf(" %s", game.Square(m.Col, m.Row))
+	}
+	h.t.Printf("\n")
+
+	move := reversi.Move{}
+	h.concession = h.t.AskMove("Your move (e.g. d3): ", state.DrawOffered(), true, func(answer string) error {
+		col, row, err := game.ParseSquare(answer, 8, 8)
+		if err != nil {
+			return err
+		}
+
+		move = reversi.Move{Col: col, Row: row}
+		if err := state.Board.IsValidMove(state.Disc, move); err != nil {
+			return fmt.Errorf("can't move there: %s", err)
+		}
+		return nil
+	})
+
+	return move
+}
+
+// Concession goes out with the move, so typing "resign" or "offer" does what it says.
+func (h *Human) Concession() game.Concession {
+	return h.concession
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, reversi.DecodeEvent)
+	h.t.Printf("\n%s", render(state.Board))
+}
+
+func render(b *reversi.Board) string {
+	return game.RenderGrid(8, 8, func(col, row int) string {
+		if b[col][row] == reversi.Empty {
+			return "."
+		}
+		return string(b[col][row])
+	})
+}
+
+func opponent(d reversi.Disc) reversi.Disc {
+	if d == reversi.Black {
+		return reversi.White
+	}
+	return reversi.Black
+}
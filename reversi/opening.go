@@ -0,0 +1,73 @@
+package reversi
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/util"
+)
+
+//go:generate go run ../cmd/openings -n 50 -plies 6 -seed 1 -o openings.txt reversi
+
+//go:embed openings.txt
+var bundledOpenings string
+
+// Openings returns the bundled suite of openings, made from random legal playouts.
+func (g *Game) Openings() []game.Opening {
+	// It's embedded, so there's nothing to go wrong reading it
+	openings, _ := game.ReadOpenings(strings.NewReader(bundledOpenings))
+	return openings
+}
+
+// RandomOpening plays random legal moves from the usual start for the given number of plies,
+// and returns the position it ends up in. A player with no moves passes, as in a real game.
+func (g *Game) RandomOpening(r *util.Rand, plies int) (string, error) {
+	// A playout can end the game early, in which case we try another
+	for tries := 0; tries < 100; tries++ {
+		b := NewBoard()
+		disc := Black
+
+		for ply := 0; ply < plies; ply++ {
+			if len(b.PossibleMoves(disc)) == 0 {
+				disc = opposite(disc)
+			}
+
+			moves := b.PossibleMoves(disc)
+			if len(moves) == 0 {
+				break
+			}
+
+			b.ApplyMove(disc, moves[r.IntBetween(0, len(moves)-1)])
+			disc = opposite(disc)
+		}
+
+		if len(b.PossibleMoves(disc)) == 0 {
+			disc = opposite(disc)
+		}
+		if len(b.PossibleMoves(disc)) > 0 {
+			return formatPosition(b, disc), nil
+		}
+	}
+
+	return "", fmt.Errorf("couldn't find an opening of %d plies that doesn't end the game", plies)
+}
+
+// formatPosition writes out a board in the format parsePosition reads.
+func formatPosition(b *Board, disc Disc) string {
+	rows := []string{}
+	for row := 7; row >= 0; row-- {
+		cells := ""
+		for col := 0; col < 8; col++ {
+			if b[col][row] == Empty {
+				cells += "."
+			} else {
+				cells += string(b[col][row])
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	return fmt.Sprintf("%s %s", strings.Join(rows, "/"), disc)
+}
@@ -0,0 +1,42 @@
+package reversi
+
+import (
+	"testing"
+
+	"github.com/boardgamesai/games/util"
+)
+
+func TestBundledOpenings(t *testing.T) {
+	openings := New().Openings()
+	if len(openings) == 0 {
+		t.Fatalf("No bundled openings")
+	}
+
+	for _, opening := range openings {
+		b, toMove, err := parsePosition(opening.Position)
+		if err != nil {
+			t.Errorf("Opening %s: %s", opening.Name, err)
+			continue
+		}
+		if position := formatPosition(b, toMove); position != opening.Position {
+			t.Errorf("Opening %s: expected %q back, got %q", opening.Name, opening.Position, position)
+		}
+	}
+}
+
+func TestRandomOpening(t *testing.T) {
+	g := New()
+
+	position, err := g.RandomOpening(util.NewRand(1), 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, _, err := parsePosition(position); err != nil {
+		t.Errorf("Got an invalid opening: %s", err)
+	}
+
+	again, _ := g.RandomOpening(util.NewRand(1), 4)
+	if again != position {
+		t.Errorf("Same seed gave different openings: %q and %q", position, again)
+	}
+}
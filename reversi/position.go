@@ -0,0 +1,70 @@
+package reversi
+
+import (
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// SetPosition has the game start from a custom position rather than the usual four discs in the
+// middle, or from the usual start again if position is "". Rows go from top to bottom, with "."
+// for an empty space, followed by the disc of whoever moves next, e.g.
+// "......../......../......../...WB.../...BW.../......../......../........ B".
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the board the game starts from, or nil for the usual one,
+// along with the index into Players of whoever moves first.
+func (g *Game) startPosition() (*Board, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	b, disc, _ := parsePosition(g.StartPosition())
+	for i, p := range g.Players {
+		if p.Disc == disc {
+			return b, i
+		}
+	}
+	return b, 0
+}
+
+func parsePosition(position string) (*Board, Disc, error) {
+	rows, fields, err := game.SplitPosition(position, 8, 8)
+	if err != nil {
+		return nil, Empty, err
+	}
+
+	if len(fields) != 1 || (Disc(fields[0]) != Black && Disc(fields[0]) != White) {
+		return nil, Empty, game.PositionError(position, "expected B or W to move after the board")
+	}
+	disc := Disc(fields[0])
+
+	b := Board{}
+	for i, row := range rows {
+		for j, cell := range row {
+			switch Disc(cell) {
+			case ".":
+			case Black, White:
+				b[j][7-i] = Disc(cell)
+			default:
+				return nil, Empty, game.PositionError(position, fmt.Sprintf("unknown space %q", cell))
+			}
+		}
+	}
+
+	if len(b.PossibleMoves(disc)) == 0 {
+		return nil, Empty, game.PositionError(position, fmt.Sprintf("%s has no moves", disc))
+	}
+
+	return &b, disc, nil
+}
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/tictactoe"
 	"github.com/boardgamesai/games/tictactoe/ai/driver"
 	"github.com/boardgamesai/games/util"
 )
 
-type AI struct{}
+type AI struct {
+	blocked bool // Whether our last move was a block
+}
 
 func (ai *AI) GetMove(state driver.State) tictactoe.Move {
+	ai.blocked = false
 	allMoves := state.Board.PossibleMoves()
 	for _, move := range allMoves {
 		// See if the opponent playing this move would be a win for them.
@@ -17,6 +21,7 @@ func (ai *AI) GetMove(state driver.State) tictactoe.Move {
 		board.ApplyMove(state.Opponent.Symbol, move) // Ignore error because we know it's a possible move
 		if hasWinner, _ := board.HasWinner(); hasWinner {
 			// We must block this move!
+			ai.blocked = true
 			return move
 		}
 	}
@@ -24,3 +29,11 @@ func (ai *AI) GetMove(state driver.State) tictactoe.Move {
 	// If we make it here, there's nothing to block, so do a random move.
 	return allMoves[util.RandInt(0, len(allMoves)-1)]
 }
+
+// Annotation says when a move was a block, which shows up alongside it in the game's event log.
+func (ai *AI) Annotation() *game.Annotation {
+	if !ai.blocked {
+		return nil
+	}
+	return &game.Annotation{Comment: "blocking"}
+}
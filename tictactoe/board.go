@@ -2,6 +2,8 @@ package tictactoe
 
 import (
 	"strings"
+
+	"github.com/boardgamesai/games/game"
 )
 
 // board is shaped like this:
@@ -149,3 +151,8 @@ func GetBoardFromString(s string) *Board {
 
 	return &b
 }
+
+// Hash fingerprints the board, so a driver can check its copy against ours.
+func (b *Board) Hash() string {
+	return game.Hash(b)
+}
@@ -1,29 +1,42 @@
 package tictactoe
 
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
 type CommsMock struct {
-	moves map[int][][]int
-	index int
+	moves   map[int][][]int
+	indexes map[int]int // Next move for each order, as either one can go first from a custom position
 }
 
 func NewCommsMock(moves map[int][][]int) *CommsMock {
 	return &CommsMock{
-		moves: moves,
-		index: -1,
+		moves:   moves,
+		indexes: map[int]int{},
 	}
 }
 
-func (c *CommsMock) Setup(p *Player, other *Player) error {
+func (c *CommsMock) Setup(ctx context.Context, p *Player, other *Player, start *Board) error {
 	return nil
 }
 
-func (c *CommsMock) GetMove(p *Player) (Move, error) {
-	if p.Order == 1 {
-		c.index++
+func (c *CommsMock) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error) {
+	if err := ctx.Err(); err != nil {
+		return Move{}, game.Concession{}, err
 	}
 
+	index := c.indexes[p.Order]
+	c.indexes[p.Order]++
+
 	move := Move{
-		Col: c.moves[p.Order][c.index][0],
-		Row: c.moves[p.Order][c.index][1],
+		Col: c.moves[p.Order][index][0],
+		Row: c.moves[p.Order][index][1],
 	}
-	return move, nil
+	return move, game.Concession{}, nil
+}
+
+func (c *CommsMock) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return nil
 }
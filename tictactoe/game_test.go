@@ -1,7 +1,11 @@
 package tictactoe
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/boardgamesai/games/game"
@@ -30,6 +34,10 @@ func TestGameWinner(t *testing.T) {
 		t.Errorf("Unexpected error: %s", err)
 	}
 
+	if g.Termination() != game.TerminationNormal {
+		t.Errorf("Expected normal termination, got: %s", g.Termination())
+	}
+
 	places := g.Places()
 	if places[0].Player.ID != g.Players[1].ID || places[0].Rank != 1 || places[0].Tie {
 		t.Errorf("Got incorrect places, player 1: %+v", places)
@@ -59,3 +67,252 @@ func TestGameTie(t *testing.T) {
 		t.Errorf("Got incorrect places, player 2: %+v", places)
 	}
 }
+
+func TestGameAborted(t *testing.T) {
+	moves := map[int][][]int{
+		1: {[]int{1, 2}, []int{2, 2}, []int{2, 1}},
+		2: {[]int{1, 1}, []int{0, 2}, []int{2, 0}},
+	}
+	g := getGame(moves)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := g.PlayContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, got: %v", err)
+	}
+	if g.Termination() != game.TerminationAborted {
+		t.Errorf("Expected aborted termination, got: %s", g.Termination())
+	}
+	if len(g.Places()) != 0 {
+		t.Errorf("Expected no places, got: %+v", g.Places())
+	}
+
+	events := g.Events()
+	if _, ok := events[len(events)-1].(game.EventAbort); !ok {
+		t.Errorf("Expected last event to be an abort, got: %s", events[len(events)-1])
+	}
+}
+
+type observer struct {
+	game.NopObserver
+	started     bool
+	events      int
+	termination game.Termination
+}
+
+func (o *observer) OnStart(name game.Name, players []*game.Player) {
+	o.started = true
+}
+
+func (o *observer) OnEvent(e game.Event) {
+	o.events++
+}
+
+func (o *observer) OnEnd(places []game.Place, termination game.Termination, err error) {
+	o.termination = termination
+}
+
+func TestGameObserver(t *testing.T) {
+	moves := map[int][][]int{
+		1: {[]int{1, 2}, []int{2, 2}, []int{2, 1}},
+		2: {[]int{1, 1}, []int{0, 2}, []int{2, 0}},
+	}
+	g := getGame(moves)
+	o := &observer{}
+	g.AddObserver(o)
+
+	if err := g.Play(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if !o.started {
+		t.Errorf("Observer wasn't told the game started")
+	}
+	if o.events != len(g.EventLog) {
+		t.Errorf("Observer saw %d events, expected %d", o.events, len(g.EventLog))
+	}
+	if o.termination != game.TerminationNormal {
+		t.Errorf("Observer got termination %s, expected normal", o.termination)
+	}
+}
+
+func TestLegalMovesNone(t *testing.T) {
+	// A tie fills the board, leaving no legal moves
+	moves := map[int][][]int{
+		1: {[]int{1, 1}, []int{2, 1}, []int{0, 2}, []int{1, 2}, []int{0, 0}},
+		2: {[]int{2, 2}, []int{0, 1}, []int{2, 0}, []int{1, 0}},
+	}
+	g := getGame(moves)
+	if err := g.Play(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Someone who asked for the legal moves can tell there aren't any from someone who didn't ask
+	for _, legalMoves := range []bool{true, false} {
+		p := g.Players[0]
+		p.LegalMoves = legalMoves
+		data, err := json.Marshal(MessageMove{LegalMoves: g.legalMoves(p)})
+		if err != nil {
+			t.Fatalf("Couldn't marshal message: %s", err)
+		}
+
+		expected := `"LegalMoves":null`
+		if legalMoves {
+			expected = `"LegalMoves":[]`
+		}
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Opted in %t: expected %s in %s", legalMoves, expected, data)
+		}
+	}
+}
+
+type checkpointObserver struct {
+	game.NopObserver
+	snapshots []*game.Snapshot
+}
+
+func (o *checkpointObserver) OnCheckpoint(s *game.Snapshot) {
+	o.snapshots = append(o.snapshots, s)
+}
+
+func TestResume(t *testing.T) {
+	moves := map[int][][]int{
+		1: {[]int{1, 2}, []int{2, 2}, []int{2, 1}},
+		2: {[]int{1, 1}, []int{0, 2}, []int{2, 0}},
+	}
+	g := getGame(moves)
+	o := &checkpointObserver{}
+	g.AddObserver(o)
+
+	if err := g.Play(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(o.snapshots) != 6 {
+		t.Fatalf("Expected a checkpoint before each of 6 moves, got %d", len(o.snapshots))
+	}
+
+	// Pick up from after the first two moves, as if it came off disk.
+	snapshotJSON, err := json.Marshal(o.snapshots[2])
+	if err != nil {
+		t.Fatalf("Couldn't marshal snapshot: %s", err)
+	}
+	s := game.Snapshot{}
+	if err := json.Unmarshal(snapshotJSON, &s); err != nil {
+		t.Fatalf("Couldn't unmarshal snapshot: %s", err)
+	}
+
+	remaining := map[int][][]int{
+		1: moves[1][1:],
+		2: moves[2][1:],
+	}
+	g2 := getGame(remaining)
+	if err := g2.Resume(context.Background(), &s); err != nil {
+		t.Fatalf("Unexpected error resuming: %s", err)
+	}
+
+	if *g2.Board != *g.Board {
+		t.Errorf("Resumed board doesn't match:\n%s\nexpected:\n%s", g2.Board, g.Board)
+	}
+	if g2.Places()[0].Player.ID != g.Places()[0].Player.ID {
+		t.Errorf("Resumed game has a different winner: %+v expected: %+v", g2.Places(), g.Places())
+	}
+	if len(g2.EventLog) != len(g.EventLog) {
+		t.Errorf("Resumed game has %d events, expected %d", len(g2.EventLog), len(g.EventLog))
+	}
+}
+
+// concedingComms plays the mock's moves, except for the given turns (counting from 0 for both players)
+// where it sends a concession along with or instead of the move.
+type concedingComms struct {
+	*CommsMock
+	turn        int
+	concessions map[int]game.Concession
+}
+
+func (c *concedingComms) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error) {
+	move, _, err := c.CommsMock.GetMove(ctx, p, hash, legalMoves, state)
+	concession := c.concessions[c.turn]
+	c.turn++
+	return move, concession, err
+}
+
+func TestConcessions(t *testing.T) {
+	moves := map[int][][]int{
+		1: {[]int{1, 2}, []int{2, 2}, []int{2, 1}},
+		2: {[]int{1, 1}, []int{0, 2}, []int{2, 0}},
+	}
+
+	tests := []struct {
+		name        string
+		concessions map[int]game.Concession
+		termination game.Termination
+		winner      int // Order of the winner, or 0 for a draw
+	}{
+		{
+			"resign",
+			map[int]game.Concession{1: {Resign: true}},
+			game.TerminationResign,
+			1,
+		},
+		{
+			"draw accepted",
+			map[int]game.Concession{0: {OfferDraw: true}, 1: {AcceptDraw: true}},
+			game.TerminationDraw,
+			0,
+		},
+		{
+			"draw not offered",
+			map[int]game.Concession{1: {AcceptDraw: true}},
+			game.TerminationDQ,
+			1,
+		},
+		{
+			"draw declined",
+			map[int]game.Concession{0: {OfferDraw: true}, 3: {AcceptDraw: true}},
+			game.TerminationDQ,
+			1,
+		},
+		{
+			"own draw",
+			map[int]game.Concession{0: {OfferDraw: true}, 2: {AcceptDraw: true}},
+			game.TerminationDQ,
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		g := getGame(moves)
+		g.Comms = &concedingComms{
+			CommsMock:   NewCommsMock(moves),
+			concessions: test.concessions,
+		}
+
+		err := g.Play()
+		if g.Termination() != test.termination {
+			t.Errorf("%s: expected %s termination, got: %s (%v)", test.name, test.termination, g.Termination(), err)
+		}
+
+		places := g.Places()
+		if len(places) != 2 {
+			t.Fatalf("%s: expected 2 places, got: %+v", test.name, places)
+		}
+		if test.winner == 0 {
+			if !places[0].Tie || !places[1].Tie {
+				t.Errorf("%s: expected a tie, got: %+v", test.name, places)
+			}
+			continue
+		}
+
+		var winner *Player
+		for _, p := range g.Players {
+			if p.Order == test.winner {
+				winner = p
+			}
+		}
+		if places[0].Player.ID != winner.ID || places[0].Rank != 1 || places[0].Tie {
+			t.Errorf("%s: expected order %d to win, got: %+v", test.name, test.winner, places)
+		}
+	}
+}
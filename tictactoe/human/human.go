@@ -0,0 +1,68 @@
+package human
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/tictactoe"
+	"github.com/boardgamesai/games/tictactoe/ai/driver"
+)
+
+// Human lets a person play Tic-Tac-Toe from the terminal, typing squares like "b2".
+type Human struct {
+	t          *game.Terminal
+	concession game.Concession // Typed along with, or instead of, the last move
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetMove(state driver.State) tictactoe.Move {
+	h.t.ShowEvents(state.NewEvents, tictactoe.DecodeEvent)
+	h.t.Printf("\n%s\nYou're %s. Legal moves:", render(state.Board), state.Symbol)
+	for _, m := range state.Board.PossibleMoves() {
+		h.t.Printf(" %s", game.Square(m.Col, m.Row))
+	}
+	h.t.Printf("\n")
+
+	move := tictactoe.Move{}
+	h.concession = h.t.AskMove("Your move (e.g. b2): ", state.DrawOffered(), true, func(answer string) error {
+		col, row, err := game.ParseSquare(answer, 3, 3)
+		if err != nil {
+			return err
+		}
+
+		move = tictactoe.Move{Col: col, Row: row}
+		if err := state.Board.IsValidMove(move); err != nil {
+			return fmt.Errorf("can't move there: %s", err)
+		}
+		return nil
+	})
+
+	return move
+}
+
+// Concession goes out with the move, so typing "resign" or "offer" does what it says.
+func (h *Human) Concession() game.Concession {
+	return h.concession
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, tictactoe.DecodeEvent)
+	h.t.Printf("\n%s", render(state.Board))
+}
+
+func render(b *tictactoe.Board) string {
+	return game.RenderGrid(3, 3, func(col, row int) string {
+		if b[col][row] == tictactoe.Empty {
+			return "."
+		}
+		return b[col][row]
+	})
+}
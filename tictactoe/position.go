@@ -0,0 +1,68 @@
+package tictactoe
+
+import (
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// SetPosition has the game start from a custom position rather than an empty board, or from the
+// usual empty board again if position is "". Rows go from top to bottom, with "." for an empty
+// space, followed by the symbol of whoever moves next, e.g. "X.O/.X./... O".
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the board the game starts from, or nil for the usual empty one,
+// along with the index into Players of whoever moves first.
+func (g *Game) startPosition() (*Board, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	b, symbol, _ := parsePosition(g.StartPosition())
+	for i, p := range g.Players {
+		if p.Symbol == symbol {
+			return b, i
+		}
+	}
+	return b, 0
+}
+
+func parsePosition(position string) (*Board, string, error) {
+	rows, fields, err := game.SplitPosition(position, 3, 3)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(fields) != 1 || (fields[0] != "X" && fields[0] != "O") {
+		return nil, "", game.PositionError(position, "expected X or O to move after the board")
+	}
+
+	b := Board{}
+	for i, row := range rows {
+		for j, cell := range row {
+			switch cell {
+			case '.':
+			case 'X', 'O':
+				b[j][2-i] = string(cell)
+			default:
+				return nil, "", game.PositionError(position, fmt.Sprintf("unknown space %q", cell))
+			}
+		}
+	}
+
+	if hasWinner, _ := b.HasWinner(); hasWinner || b.IsFull() {
+		return nil, "", game.PositionError(position, "the game is already over")
+	}
+
+	return &b, fields[0], nil
+}
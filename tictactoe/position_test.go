@@ -0,0 +1,107 @@
+package tictactoe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boardgamesai/games/game"
+)
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		position string
+		valid    bool
+	}{
+		{"X.O/.X./... O", true},
+		{"X../.../... O", true},
+		{"X.O/.X./... ", false},   // No one to move
+		{"X.O/.X./... Z", false},  // Bad symbol to move
+		{"X.O/.X. O", false},      // Too few rows
+		{"X.O/.X../... O", false}, // Row too long
+		{"X.O/.Z./... O", false},  // Bad space
+		{"XXX/OO./... O", false},  // Already won
+		{"XOX/XOO/OXX X", false},  // Board full
+	}
+
+	for _, test := range tests {
+		_, _, err := parsePosition(test.position)
+		if test.valid && err != nil {
+			t.Errorf("%q: unexpected error: %s", test.position, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%q: expected an error", test.position)
+		}
+	}
+}
+
+func TestGameFromPosition(t *testing.T) {
+	// O is to move, and X wins on the diagonal once O goes elsewhere
+	moves := map[int][][]int{
+		1: {[]int{2, 0}},
+		2: {[]int{1, 2}},
+	}
+	g := getGame(moves)
+
+	if err := g.SetPosition("X.O/.X./... O"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if err := g.Play(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if g.Termination() != game.TerminationNormal {
+		t.Errorf("Expected normal termination, got: %s", g.Termination())
+	}
+
+	places := g.Places()
+	if places[0].Player.ID != g.Players[0].ID || places[0].Tie {
+		t.Errorf("Expected X to win, got: %+v", places)
+	}
+
+	if g.Board[1][2] != "O" || g.Board[2][0] != "X" {
+		t.Errorf("Moves not played on the starting board:\n%s", g.Board)
+	}
+
+	if err := g.SetPosition("XXX/OO./... O"); err == nil {
+		t.Errorf("Expected an error setting a finished position")
+	}
+	if g.StartPosition() != "X.O/.X./... O" {
+		t.Errorf("Invalid position replaced the old one: %q", g.StartPosition())
+	}
+}
+
+func TestPlayOpenings(t *testing.T) {
+	// O is to move, and X wins on the diagonal once O goes elsewhere, in both games
+	moves := map[int][][]int{
+		1: {[]int{2, 0}, []int{2, 0}},
+		2: {[]int{1, 2}, []int{1, 2}},
+	}
+	g := getGame(moves)
+
+	openings := []game.Opening{{Name: "diagonal", Position: "X.O/.X./... O"}}
+	results, err := game.PlayOpenings(context.Background(), g, openings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(results) != 1 || len(results[0].Games) != 2 {
+		t.Fatalf("Expected one opening played twice, got: %+v", results)
+	}
+
+	// Whoever has X wins, so swapping seats gives each player one win
+	for i, played := range results[0].Games {
+		if played.Places[0].Player.ID != played.Seats[0] {
+			t.Errorf("Game %d: expected %d to win, got: %+v", i+1, played.Seats[0], played.Places)
+		}
+	}
+	if results[0].Games[0].Seats[0] == results[0].Games[1].Seats[0] {
+		t.Errorf("Seats weren't swapped: %+v", results[0].Games)
+	}
+	if results[0].Score(1) != 1 || results[0].Score(2) != 1 {
+		t.Errorf("Expected 1 point each, got %.1f and %.1f", results[0].Score(1), results[0].Score(2))
+	}
+
+	if g.StartPosition() != "" {
+		t.Errorf("Expected the game back at the usual start, got: %q", g.StartPosition())
+	}
+}
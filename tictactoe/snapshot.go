@@ -0,0 +1,38 @@
+package tictactoe
+
+import (
+	"context"
+
+	"github.com/boardgamesai/games/game"
+)
+
+// snapshotState is what we need on top of the game package's snapshot to pick up where we left off.
+type snapshotState struct {
+	Board *Board
+	Turn  int // Index into Players of whose move it is
+}
+
+// Resume picks up a game from a snapshot, such as one from LastCheckpoint. The players are
+// relaunched and sent the whole event history with their next move request.
+func (g *Game) Resume(ctx context.Context, s *game.Snapshot) error {
+	return g.Finish(ctx, g.play(ctx, s))
+}
+
+func (g *Game) checkpoint(turn int) {
+	g.Checkpoint(snapshotState{
+		Board: g.Board,
+		Turn:  turn,
+	})
+}
+
+func (g *Game) restore(s *game.Snapshot) (int, error) {
+	state := snapshotState{}
+	if err := g.Restore(s, &state); err != nil {
+		return 0, err
+	}
+
+	g.Board = state.Board
+	g.assignSeats()
+
+	return state.Turn, nil
+}
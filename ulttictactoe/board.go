@@ -4,6 +4,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/boardgamesai/games/game"
 	"github.com/boardgamesai/games/tictactoe"
 )
 
@@ -144,6 +145,19 @@ func (b *Board) IsFull() bool {
 	return len(b.Grid.PossibleMoves()) == 0
 }
 
+// SubgridsWon counts how many of the small boards each symbol has won.
+func (b *Board) SubgridsWon() map[string]int {
+	won := map[string]int{}
+	for i := range b.Grid {
+		for j := range b.Grid[i] {
+			if symbol := b.Grid[i][j]; symbol != tictactoe.Empty && symbol != Full {
+				won[symbol]++
+			}
+		}
+	}
+	return won
+}
+
 func (b *Board) PossibleMoves() []Move {
 	moves := []Move{}
 	subgrids := map[*Coords]*tictactoe.Board{}
@@ -257,3 +271,8 @@ func GetBoardFromString(s string) *Board {
 
 	return b
 }
+
+// Hash fingerprints the board, so a driver can check its copy against ours.
+func (b *Board) Hash() string {
+	return game.Hash(b)
+}
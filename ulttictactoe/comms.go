@@ -1,6 +1,7 @@
 package ulttictactoe
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/boardgamesai/games/game"
@@ -12,33 +13,43 @@ type Comms struct {
 
 func NewComms(g *Game) *Comms {
 	return &Comms{
-		Comms: game.Comms{
-			EventLog: &(g.EventLog),
-		},
+		Comms: g.BaseComms(),
 	}
 }
 
-func (c *Comms) Setup(p *Player, other *Player) error {
+func (c *Comms) Setup(ctx context.Context, p *Player, other *Player, start *Board) error {
 	message := MessageSetup{
 		Symbol:   p.Symbol,
 		Order:    p.Order,
 		ID:       p.ID,
 		Opponent: other,
+		Board:    start,
 	}
-	return p.SendMessageNoResponse(message)
+	return c.SendMessageNoResponse(ctx, &p.Player, message)
 }
 
-func (c *Comms) GetMove(p *Player) (Move, error) {
-	move := Move{}
+func (c *Comms) GetMove(ctx context.Context, p *Player, hash string, legalMoves []Move, state *PlayerState) (Move, game.Concession, error) {
+	// Any concession comes in the same object as the move, e.g. {"Col":1,"Row":2,"OfferDraw":true}
+	response := struct {
+		Move
+		game.Concession
+	}{}
 
 	message := MessageMove{
-		NewEvents: c.NewEvents(p.ID),
+		NewEvents:  c.NewEvents(p.ID),
+		Hash:       hash,
+		LegalMoves: legalMoves,
+		State:      state,
 	}
-	responseJSON, err := p.SendMessage(message)
+	responseJSON, err := c.SendMessage(ctx, &p.Player, message)
 	if err != nil {
-		return move, err
+		return response.Move, response.Concession, err
 	}
 
-	err = json.Unmarshal(responseJSON, &move)
-	return move, err
+	err = json.Unmarshal(responseJSON, &response)
+	return response.Move, response.Concession, err
+}
+
+func (c *Comms) GameOver(ctx context.Context, p *Player, termination game.Termination, places []game.Place) error {
+	return c.SendGameOver(ctx, &p.Player, termination, places)
 }
@@ -0,0 +1,88 @@
+package human
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/tictactoe"
+	"github.com/boardgamesai/games/ulttictactoe"
+	"github.com/boardgamesai/games/ulttictactoe/ai/driver"
+)
+
+// Human lets a person play Ultimate Tic-Tac-Toe from the terminal. The whole board is one 9x9 grid,
+// so squares are typed like "e5", which is the middle of the middle board.
+type Human struct {
+	t          *game.Terminal
+	concession game.Concession // Typed along with, or instead of, the last move
+}
+
+func New(t *game.Terminal) *game.HumanRunnable {
+	return game.NewHumanRunnable(func(in io.Reader, out io.Writer) {
+		d := driver.New(&Human{t: t})
+		d.SetIO(in, out, io.Discard)
+		d.Run()
+	})
+}
+
+func (h *Human) GetMove(state driver.State) ulttictactoe.Move {
+	h.t.ShowEvents(state.NewEvents, ulttictactoe.DecodeEvent)
+	h.t.Printf("\n%s\nYou're %s. Legal moves:", render(state.Board), state.Symbol)
+	if state.Board.NextPlay == nil {
+		h.t.Printf(" any open square in a board that's still undecided")
+	}
+
+	squares := []string{}
+	for _, m := range state.Board.PossibleMoves() {
+		squares = append(squares, game.Square(m.Col*3+m.SubCol, m.Row*3+m.SubRow))
+	}
+	sort.Strings(squares)
+	for _, s := range squares {
+		if state.Board.NextPlay == nil {
+			break
+		}
+		h.t.Printf(" %s", s)
+	}
+	h.t.Printf("\n")
+
+	move := ulttictactoe.Move{}
+	h.concession = h.t.AskMove("Your move (e.g. e5): ", state.DrawOffered(), true, func(answer string) error {
+		col, row, err := game.ParseSquare(answer, 9, 9)
+		if err != nil {
+			return err
+		}
+
+		move = ulttictactoe.Move{Col: col / 3, Row: row / 3, SubCol: col % 3, SubRow: row % 3}
+		if err := state.Board.IsValidMove(move); err != nil {
+			return fmt.Errorf("can't move there: %s", err)
+		}
+		return nil
+	})
+
+	return move
+}
+
+// Concession goes out with the move, so typing "resign" or "offer" does what it says.
+func (h *Human) Concession() game.Concession {
+	return h.concession
+}
+
+func (h *Human) GameOver(state driver.State, gameOver game.MessageGameOver) {
+	h.t.ShowGameOver(gameOver, ulttictactoe.DecodeEvent)
+	h.t.Printf("\n%s", render(state.Board))
+}
+
+// render leaves the empty squares of boards that are already decided blank, since they can't be played.
+func render(b *ulttictactoe.Board) string {
+	return game.RenderGrid(9, 9, func(col, row int) string {
+		cell := b.SubGrids[col/3][row/3][col%3][row%3]
+		if cell == tictactoe.Empty {
+			if b.Grid[col/3][row/3] != tictactoe.Empty {
+				return " "
+			}
+			return "."
+		}
+		return cell
+	})
+}
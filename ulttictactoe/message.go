@@ -7,8 +7,22 @@ type MessageSetup struct {
 	Order    int
 	ID       game.PlayerID
 	Opponent *Player
+	Board    *Board `json:",omitempty"` // Only set when starting from a custom position
 }
 
 type MessageMove struct {
-	NewEvents []game.Event
+	NewEvents  []game.Event
+	Hash       string       // Of the board once NewEvents are applied, so the driver can check it's in sync
+	LegalMoves []Move       // Only for players who opted in with LegalMoves, null for everyone else
+	State      *PlayerState `json:",omitempty"` // Only for players who opted in with FullState
+}
+
+// PlayerState is everything the player needs to decide on a move, so a client doesn't have to
+// keep track of the game itself. It's always their turn when they get it.
+type PlayerState struct {
+	ID       game.PlayerID
+	Symbol   string
+	Order    int
+	Opponent *Player
+	Board    *Board
 }
@@ -0,0 +1,93 @@
+package ulttictactoe
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/tictactoe"
+	"github.com/boardgamesai/games/util"
+)
+
+//go:generate go run ../cmd/openings -n 50 -plies 4 -seed 1 -o openings.txt ulttictactoe
+
+//go:embed openings.txt
+var bundledOpenings string
+
+// Openings returns the bundled suite of openings, made from random legal playouts.
+func (g *Game) Openings() []game.Opening {
+	// It's embedded, so there's nothing to go wrong reading it
+	openings, _ := game.ReadOpenings(strings.NewReader(bundledOpenings))
+	return openings
+}
+
+// RandomOpening plays random legal moves from an empty board for the given number of plies,
+// and returns the position it ends up in.
+func (g *Game) RandomOpening(r *util.Rand, plies int) (string, error) {
+	// A playout can end the game early, in which case we try another
+	for tries := 0; tries < 100; tries++ {
+		b := NewBoard()
+		symbol := "X"
+
+		for ply := 0; ply < plies; ply++ {
+			moves := b.PossibleMoves()
+			if len(moves) == 0 {
+				break
+			}
+
+			// PossibleMoves comes back in no particular order, and we want the same seed to
+			// give the same opening.
+			sort.Slice(moves, func(i, j int) bool {
+				a, b := moves[i], moves[j]
+				if a.Col != b.Col {
+					return a.Col < b.Col
+				}
+				if a.Row != b.Row {
+					return a.Row < b.Row
+				}
+				if a.SubCol != b.SubCol {
+					return a.SubCol < b.SubCol
+				}
+				return a.SubRow < b.SubRow
+			})
+
+			b.ApplyMove(symbol, moves[r.IntBetween(0, len(moves)-1)])
+			if symbol == "X" {
+				symbol = "O"
+			} else {
+				symbol = "X"
+			}
+		}
+
+		if hasWinner, _ := b.HasWinner(); !hasWinner && !b.IsFull() {
+			return formatPosition(b, symbol), nil
+		}
+	}
+
+	return "", fmt.Errorf("couldn't find an opening of %d plies that doesn't end the game", plies)
+}
+
+// formatPosition writes out a board in the format parsePosition reads.
+func formatPosition(b *Board, symbol string) string {
+	rows := []string{}
+	for y := 8; y >= 0; y-- {
+		cells := ""
+		for x := 0; x < 9; x++ {
+			cell := b.SubGrids[x/3][y/3][x%3][y%3]
+			if cell == tictactoe.Empty {
+				cells += "."
+			} else {
+				cells += cell
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	position := fmt.Sprintf("%s %s", strings.Join(rows, "/"), symbol)
+	if b.NextPlay != nil {
+		position += fmt.Sprintf(" %d,%d", b.NextPlay.Col, b.NextPlay.Row)
+	}
+	return position
+}
@@ -0,0 +1,96 @@
+package ulttictactoe
+
+import (
+	"fmt"
+
+	"github.com/boardgamesai/games/game"
+	"github.com/boardgamesai/games/tictactoe"
+)
+
+// SetPosition has the game start from a custom position rather than an empty board, or from the
+// usual empty board again if position is "". The nine rows go from top to bottom across the whole
+// board, with "." for an empty space, followed by the symbol of whoever moves next and optionally
+// the col,row of the subgrid they have to play in, e.g. "O 1,1" for the middle one. Without it
+// they can play anywhere.
+func (g *Game) SetPosition(position string) error {
+	if position != "" {
+		if _, _, err := parsePosition(position); err != nil {
+			return err
+		}
+	}
+
+	g.SetStartPosition(position)
+	return nil
+}
+
+// startPosition returns the board the game starts from, or nil for the usual empty one,
+// along with the index into Players of whoever moves first.
+func (g *Game) startPosition() (*Board, int) {
+	if g.StartPosition() == "" {
+		return nil, 0
+	}
+
+	// We've already validated this in SetPosition
+	b, symbol, _ := parsePosition(g.StartPosition())
+	for i, p := range g.Players {
+		if p.Symbol == symbol {
+			return b, i
+		}
+	}
+	return b, 0
+}
+
+func parsePosition(position string) (*Board, string, error) {
+	rows, fields, err := game.SplitPosition(position, 9, 9)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(fields) < 1 || len(fields) > 2 || (fields[0] != "X" && fields[0] != "O") {
+		return nil, "", game.PositionError(position, "expected X or O to move after the board")
+	}
+
+	b := NewBoard()
+	for i, row := range rows {
+		y := 8 - i
+		for x, cell := range row {
+			switch cell {
+			case '.':
+			case 'X', 'O':
+				b.SubGrids[x/3][y/3][x%3][y%3] = string(cell)
+			default:
+				return nil, "", game.PositionError(position, fmt.Sprintf("unknown space %q", cell))
+			}
+		}
+	}
+
+	// Set up the tracking grid from the subgrids
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			grid := b.SubGrids[col][row]
+			if hasWinner, winMoves := grid.HasWinner(); hasWinner {
+				move := winMoves[0]
+				b.Grid[col][row] = grid[move.Col][move.Row]
+			} else if grid.IsFull() {
+				b.Grid[col][row] = Full
+			}
+		}
+	}
+
+	if len(fields) == 2 {
+		col, row := -1, -1
+		if _, err := fmt.Sscanf(fields[1], "%d,%d", &col, &row); err != nil || col < 0 || col > 2 || row < 0 || row > 2 {
+			return nil, "", game.PositionError(position, fmt.Sprintf("invalid subgrid %q, expected col,row from 0 to 2", fields[1]))
+		}
+		if b.Grid[col][row] != tictactoe.Empty {
+			return nil, "", game.PositionError(position, fmt.Sprintf("subgrid %s is already finished", fields[1]))
+		}
+		b.NextPlay = getCoords(col, row)
+	}
+
+	if hasWinner, _ := b.HasWinner(); hasWinner || b.IsFull() {
+		return nil, "", game.PositionError(position, "the game is already over")
+	}
+
+	return b, fields[0], nil
+}
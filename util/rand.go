@@ -0,0 +1,56 @@
+package util
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Rand is a seeded source of randomness whose state can be saved and restored, for anything
+// that needs to be reproducible. RandInt and Shuffle use crypto/rand and can't be.
+type Rand struct {
+	*rand.Rand
+	pcg *rand.PCG
+}
+
+func NewRand(seed uint64) *Rand {
+	pcg := rand.NewPCG(seed, seed)
+	return &Rand{
+		Rand: rand.New(pcg),
+		pcg:  pcg,
+	}
+}
+
+// RandSeed returns a random seed for NewRand.
+func RandSeed() uint64 {
+	b := make([]byte, 8)
+	if _, err := crand.Read(b); err != nil {
+		// Don't bother returning this, if we're here something is deeply wrong
+		panic(fmt.Sprintf("error getting random seed: %s", err))
+	}
+
+	return binary.LittleEndian.Uint64(b)
+}
+
+// IntBetween returns a random int in the range [min, max]
+func (r *Rand) IntBetween(min, max int) int {
+	return r.IntN(max-min+1) + min
+}
+
+func (r *Rand) MarshalBinary() ([]byte, error) {
+	return r.pcg.MarshalBinary()
+}
+
+func (r *Rand) UnmarshalBinary(data []byte) error {
+	return r.pcg.UnmarshalBinary(data)
+}
+
+// ShuffleWith is Shuffle, but using r.
+func ShuffleWith[T any](r *Rand, s []T) {
+	maxlen := len(s) - 1 // -1 because the last element can only be swapped with itself
+	for i := 0; i < maxlen; i++ {
+		j := r.IntBetween(i, maxlen)
+		s[i], s[j] = s[j], s[i]
+	}
+}